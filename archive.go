@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marcos-venicius/daily-term/taskmanagement"
+	"github.com/nsf/termbox-go"
+)
+
+func (editor *Editor) SetArchiveMode() {
+	if editor.archive.IsEmpty() {
+		editor.SetInfoMessage("the archive is empty")
+		return
+	}
+
+	editor.archiveSelection = 0
+	editor.CloseDetails()
+	editor.mode = ArchiveMode
+}
+
+func (mode *EditorMode) IsArchive() bool {
+	return *mode == ArchiveMode
+}
+
+// moves tasks already taken from the board to the archive and saves both,
+// when something fails the tasks go back to the board
+func (editor *Editor) moveToArchive(tasks []*taskmanagement.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	rollback := func() {
+		for _, task := range tasks {
+			editor.archive.Take(task.Id)
+			editor.board.RestoreTask(task)
+		}
+
+		editor.board.ForgetUndoPoints()
+	}
+
+	editor.archive.Add(tasks, time.Now())
+
+	if err := editor.repository.SaveArchive(editor.archive); err != nil {
+		rollback()
+		return err
+	}
+
+	if err := editor.repository.SaveBoard(editor.board); err != nil {
+		rollback()
+		editor.repository.SaveArchive(editor.archive)
+		return err
+	}
+
+	return nil
+}
+
+func (editor *Editor) archiveFinishedTasks() {
+	if editor.refuseReadOnly() {
+		return
+	}
+
+	tasks := editor.board.TakeFinishedTasks(time.Now())
+
+	if len(tasks) == 0 {
+		editor.SetInfoMessage("there are no finished tasks to archive")
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.moveToArchive(tasks)) {
+		return
+	}
+
+	editor.SetInfoMessage(fmt.Sprintf("%d task(s) archived successfully", len(tasks)))
+}
+
+// archives the tasks expired by the automatic archive policy
+func (editor *Editor) archiveExpiredTasks() {
+	tasks := editor.board.TakeExpiredTasks(time.Now())
+
+	if !editor.setErrorMessageIfNNil(editor.moveToArchive(tasks)) && len(tasks) > 0 {
+		editor.SetInfoMessage(fmt.Sprintf("%d task(s) archived automatically", len(tasks)))
+	}
+}
+
+func (editor *Editor) restoreArchivedTask(id int) {
+	if editor.refuseReadOnly() {
+		return
+	}
+
+	task, err := editor.archive.Take(id)
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	editor.board.RestoreTask(task)
+
+	rollback := func() {
+		editor.board.RevertLastChange()
+		editor.archive.Add([]*taskmanagement.Task{task}, time.Now())
+	}
+
+	// the archive is written first, so a failure leaves the task in the archive and not in both files
+	if editor.setErrorMessageIfNNil(editor.repository.SaveArchive(editor.archive)) {
+		rollback()
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		rollback()
+		editor.repository.SaveArchive(editor.archive)
+		return
+	}
+
+	// undoing the restore would lose the task, it is not in the archive anymore
+	editor.board.ForgetUndoPoints()
+
+	editor.SetInfoMessage("task restored successfully")
+}
+
+func (editor *Editor) listenArchiveModeEvents(event termbox.Event) {
+	if !editor.running {
+		return
+	}
+
+	if event.Type != termbox.EventKey {
+		return
+	}
+
+	entries := editor.archive.Entries()
+
+	if event.Key == termbox.KeyEsc {
+		editor.SetNormalMode()
+		return
+	}
+
+	switch event.Ch {
+	case 'q':
+		editor.SetNormalMode()
+		break
+	case 'j':
+		if editor.archiveSelection < len(entries)-1 {
+			editor.archiveSelection++
+		}
+		break
+	case 'k':
+		if editor.archiveSelection > 0 {
+			editor.archiveSelection--
+		}
+		break
+	case 'r':
+		if editor.archiveSelection < len(entries) {
+			editor.SetNormalMode()
+			editor.restoreArchivedTask(entries[editor.archiveSelection].Task.Id)
+		}
+		break
+	default:
+		break
+	}
+}
+
+func (editor *Editor) DisplayArchive() {
+	const startingRow = 2
+
+	for row, entry := range editor.archive.Entries() {
+		task := entry.Task
+
+		selectedSymbol := ' '
+		color := termbox.ColorWhite
+
+		if row == editor.archiveSelection {
+			selectedSymbol = '*'
+			color = termbox.ColorYellow
+		}
+
+		archivedAt := time.Unix(entry.ArchivedAt, 0).Format(taskmanagement.DueDateLayout)
+		subtasks := ""
+
+		if count := len(task.Subtasks()); count > 0 {
+			subtasks = fmt.Sprintf(" (+%d)", count)
+		}
+
+		text := fmt.Sprintf("%c [%04d] %v%v (archived %v)", selectedSymbol, task.Id, task.Name, subtasks, archivedAt)
+
+		tbprintn(0, startingRow+row, editor.width, color, termbox.ColorDefault, text)
+	}
+}
@@ -95,6 +95,16 @@ func (cmd *ArgumentParser) Finish() error {
 	return nil
 }
 
+// inside quotes, \" stands for a quote and \\ for a backslash
+func unescapeQuoted(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(text)
+}
+
+// escapes the text so it can be written between quotes in a command
+func EscapeQuoted(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text)
+}
+
 func nextArgument(text string, argumentName string) (string, int, error) {
 	pad := len(text)
 	text = strings.TrimSpace(text)
@@ -106,8 +116,19 @@ func nextArgument(text string, argumentName string) (string, int, error) {
 
 	var quotes []rune
 	hasQuotes := false
+	escaped := false
 
 	for index, ch := range text {
+		if escaped {
+			escaped = false
+			continue
+		}
+
+		if ch == '\\' && len(quotes) > 0 {
+			escaped = true
+			continue
+		}
+
 		if ch == '"' {
 			hasQuotes = true
 
@@ -120,7 +141,7 @@ func nextArgument(text string, argumentName string) (string, int, error) {
 
 		if ch == ' ' && len(quotes) == 0 {
 			if hasQuotes {
-				return text[1 : index-1], pad + index + 1, nil
+				return unescapeQuoted(text[1 : index-1]), pad + index + 1, nil
 			}
 			return text[:index], pad + index + 1, nil
 		}
@@ -128,7 +149,7 @@ func nextArgument(text string, argumentName string) (string, int, error) {
 
 	if len(quotes) == 0 {
 		if hasQuotes {
-			return text[1 : len(text)-1], pad + len(text), nil
+			return unescapeQuoted(text[1 : len(text)-1]), pad + len(text), nil
 		}
 
 		return text, pad + len(text), nil
@@ -469,3 +469,35 @@ func TestParseFromStringWhenHavingValidCommandWithValidBooleanType(t *testing.T)
 		}
 	}
 }
+
+func TestParseFromStringWithEscapedQuotes(t *testing.T) {
+	cmd := CreateArgumentParser()
+
+	cmd.AddCommand(
+		"rename",
+		CommandArgumentSyntax{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     IntArgumentType,
+		},
+		CommandArgumentSyntax{
+			Name:     "Task name (string)",
+			Required: true,
+			Type:     StringArgumentType,
+		},
+	)
+
+	cmd.Finish()
+
+	for _, name := range []string{`the "big" one`, `C:\dir`, `ends with \`, `"`} {
+		command, err := cmd.ParseFromString(fmt.Sprintf(`rename 12 "%v"`, EscapeQuoted(name)))
+
+		if err != nil {
+			t.Fatalf(`Expected: nil, Received: "%v"`, err.Error())
+		}
+
+		if command.Arguments[1].Value != name {
+			t.Fatalf(`Expected: "%v", Received: "%v"`, name, command.Arguments[1].Value)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marcos-venicius/daily-term/argumentparser"
+)
+
+// without a number it lists the backups, otherwise it rolls the database back to the given backup
+func (editor *Editor) restoreBackup(arguments []argumentparser.CommandArgument) {
+	if len(arguments) == 0 {
+		editor.listBackups()
+		return
+	}
+
+	number := arguments[0].Value.(int)
+
+	if editor.setErrorMessageIfNNil(editor.repository.RestoreBackup(number)) {
+		return
+	}
+
+	name, err := editor.repository.ActiveBoardName()
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	editor.openBoard(name)
+
+	if editor.errorMessage == "" {
+		editor.SetInfoMessage(fmt.Sprintf("backup %d restored, use :restore-backup 1 to go back", number))
+	}
+}
+
+func (editor *Editor) listBackups() {
+	backups := editor.repository.Backups()
+
+	if len(backups) == 0 {
+		editor.SetInfoMessage("there are no backups yet")
+		return
+	}
+
+	items := make([]string, 0, len(backups))
+
+	for _, backup := range backups {
+		items = append(items, fmt.Sprintf("%d (%v)", backup.Number, backup.SavedAt.Format("2006-01-02 15:04:05")))
+	}
+
+	editor.SetInfoMessage(fmt.Sprintf("backups: %v", strings.Join(items, " ")))
+}
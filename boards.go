@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marcos-venicius/daily-term/argumentparser"
+	"github.com/marcos-venicius/daily-term/taskmanagement"
+)
+
+// loads the board with the given name and makes it the board shown by the editor
+func (editor *Editor) openBoard(name string) {
+	board := taskmanagement.CreateNamedBoard(name)
+
+	board.SetWorkflow(editor.workflow)
+
+	editor.showBanner(editor.repository.LoadBoard(board))
+
+	board.ReserveTaskIds(editor.archive.TaskIds())
+
+	editor.board = board
+	editor.liveBoard = nil
+	editor.historyTaskId = followSelectedTask
+
+	now := time.Now()
+
+	// on the first launch of a day, the previous day is kept and its unfinished tasks are carried over
+	changed, finished := board.StartDay(now)
+
+	// recurring tasks whose day has passed get their next instance when the board is loaded
+	if spawned := board.SpawnPastOccurrences(now); len(spawned) > 0 {
+		changed = true
+	}
+
+	// the tasks finished on the previous day are archived like with :archive, which saves the board too
+	if len(finished) > 0 {
+		editor.setErrorMessageIfNNil(editor.moveToArchive(finished))
+	} else if changed {
+		editor.setErrorMessageIfNNil(editor.repository.SaveBoard(board))
+	}
+}
+
+// loads the changes other instances saved to the current board, keeping the filters, the task shown in the
+// history pane and the selected task when it still exists. an open past day stays open.
+// the undo history cannot be kept since it was made from the replaced tasks
+func (editor *Editor) reloadBoard() {
+	live := editor.currentDayBoard()
+	shown := editor.board
+	selectedId := live.SelectedTaskId()
+	historyTaskId := editor.historyTaskId
+
+	editor.openBoard(live.Name())
+
+	editor.board.CopyFilters(live)
+	editor.historyTaskId = historyTaskId
+
+	if selectedId != nil {
+		editor.board.SelectTask(*selectedId)
+	}
+
+	if live.CanUndo() || live.CanRedo() {
+		editor.showBanner(errors.New("The board was reloaded, the changes made before cannot be undone anymore"))
+	}
+
+	if shown != live {
+		editor.liveBoard = editor.board
+		editor.board = shown
+	}
+}
+
+func (editor *Editor) reload() {
+	editor.reloadBoard()
+
+	if editor.errorMessage == "" {
+		editor.SetInfoMessage("board reloaded")
+	}
+}
+
+func (editor *Editor) listBoards() {
+	names, err := editor.repository.BoardNames()
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	for index, name := range names {
+		if name == editor.board.Name() {
+			names[index] = fmt.Sprintf("[%v]", name)
+		}
+	}
+
+	editor.SetInfoMessage(fmt.Sprintf("boards: %v", strings.Join(names, " ")))
+}
+
+func (editor *Editor) switchBoard(name string) {
+	if name == editor.board.Name() {
+		editor.SetErrorMessage(fmt.Sprintf(`You are already on board "%v"`, name))
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SetActiveBoard(name)) {
+		return
+	}
+
+	editor.openBoard(name)
+
+	if editor.errorMessage == "" {
+		editor.SetInfoMessage(fmt.Sprintf(`switched to board "%v"`, name))
+	}
+}
+
+func (editor *Editor) newBoard(name string) {
+	if editor.setErrorMessageIfNNil(editor.repository.AddBoard(name)) {
+		return
+	}
+
+	editor.switchBoard(name)
+}
+
+// with a single name it renames the active board
+func (editor *Editor) renameBoard(arguments []argumentparser.CommandArgument) {
+	name := editor.board.Name()
+	newName := arguments[0].Value.(string)
+
+	if len(arguments) > 1 {
+		name, newName = newName, arguments[1].Value.(string)
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.RenameBoard(name, newName)) {
+		return
+	}
+
+	if name == editor.board.Name() {
+		editor.board.SetName(newName)
+		editor.currentDayBoard().SetName(newName)
+	}
+
+	editor.SetInfoMessage(fmt.Sprintf(`board "%v" renamed to "%v"`, name, newName))
+}
+
+func (editor *Editor) deleteBoard(name string) {
+	if editor.setErrorMessageIfNNil(editor.repository.DeleteBoard(name)) {
+		return
+	}
+
+	editor.SetInfoMessage(fmt.Sprintf(`board "%v" deleted`, name))
+}
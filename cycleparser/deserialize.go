@@ -190,6 +190,9 @@ func (vu *valueUnmapper) fromSliceValue(data *Value, v reflect.Value) error {
 	if data.Value == nil {
 		return nil
 	}
+	if v.Kind() != reflect.Slice {
+		return &InvalidUnmapperKindError{Expected: Slice, Kind: v.Kind().String()}
+	}
 	var sl reflect.Value
 	mi, ok := data.Value.([]any)
 	if ok {
@@ -209,12 +212,12 @@ func (vu *valueUnmapper) fromSliceValue(data *Value, v reflect.Value) error {
 		el := v.Index(i)
 		if mv != nil {
 			x = mv[i]
-		} else {
-			x = mi[i].(*Value)
+		} else if x, ok = mi[i].(*Value); !ok {
+			return &InvalidValueError{Value: data.Value, Kind: data.Kind}
 		}
 		err := vu.fromValue(x, el)
 		if err != nil {
-			return nil
+			return err
 		}
 	}
 
@@ -247,13 +250,13 @@ func (vu *valueUnmapper) fromMapValue(data *Value, v reflect.Value) error {
 		var x *Value
 		if mv != nil {
 			x = mv[key.String()]
-		} else {
-			x = mi[key.String()].(*Value)
+		} else if x, ok = mi[key.String()].(*Value); !ok {
+			return &InvalidValueError{Value: data.Value, Kind: data.Kind}
 		}
 		f := reflect.New(v.Type().Elem()).Elem()
 		err := vu.fromValue(x, f)
 		if err != nil {
-			return nil
+			return err
 		}
 		fmt.Println(key, f)
 		v.SetMapIndex(key, f)
@@ -267,6 +270,9 @@ func (vu *valueUnmapper) fromPtrValue(data *Value, v reflect.Value) error {
 	if data.Value == nil {
 		return nil
 	}
+	if v.Kind() != reflect.Ptr {
+		return &InvalidUnmapperKindError{Expected: Ptr, Kind: v.Kind().String()}
+	}
 
 	el := v.Elem()
 	if !el.IsValid() {
@@ -276,7 +282,10 @@ func (vu *valueUnmapper) fromPtrValue(data *Value, v reflect.Value) error {
 		v.Set(elm)
 		el = v.Elem()
 	}
-	x := data.Value.(*Value)
+	x, ok := data.Value.(*Value)
+	if !ok {
+		return &InvalidValueError{Value: data.Value, Kind: data.Kind}
+	}
 	err := vu.fromValue(x, el)
 	if err != nil {
 		return err
@@ -333,8 +342,8 @@ func (vu *valueUnmapper) fromStructValue(data *Value, v reflect.Value) error {
 		var x *Value
 		if mv != nil {
 			x = mv[tagName]
-		} else {
-			x = mi[tagName].(*Value)
+		} else if x, ok = mi[tagName].(*Value); !ok {
+			return &InvalidValueError{Value: data.Value, Kind: data.Kind}
 		}
 		if f.IsValid() {
 			err := vu.fromValue(x, f)
@@ -348,8 +357,14 @@ func (vu *valueUnmapper) fromStructValue(data *Value, v reflect.Value) error {
 }
 
 func (vu *valueUnmapper) fromRefValue(data *Value, v reflect.Value) error {
-	ref := data.Value.(*Reference)
+	ref, ok := data.Value.(*Reference)
+	if !ok {
+		return &InvalidValueError{Value: data.Value, Kind: data.Kind}
+	}
 	if refv, ok := vu.refs[ref.Refid]; ok {
+		if !refv.Type().AssignableTo(v.Type()) {
+			return &InvalidUnmapperKindError{Expected: refv.Type().String(), Kind: v.Type().String()}
+		}
 		v.Set(refv)
 		return nil
 	}
@@ -368,7 +383,7 @@ func (vu *valueUnmapper) fromValue(data *Value, v reflect.Value) error {
 	switch data.Kind {
 	case Bool:
 		return vu.fromBoolValue(data, v)
-	case Int, Int8, Int16, Int32, Int64, TaskState:
+	case Int, Int8, Int16, Int32, Int64, TaskState, TaskPriority:
 		return vu.fromIntValue(data, v)
 	case Uint, Uint8, Uint16, Uint32, Uint64:
 		return vu.fromUintValue(data, v)
@@ -336,6 +336,64 @@ func fromValueTests() []fromValueTest {
 		err: `cycleparser.Value: invalid value struct {}(struct {}{}) for kind "string"`,
 	})
 
+	v4 := 0
+	result = append(result, fromValueTest{
+		in: &cycleparser.Value{
+			Refid: 1,
+			Kind:  cycleparser.Ptr,
+			Value: "invalid",
+		},
+		out: &v4,
+		err: `cycleparser.FromValue: cycleparser.Resolver: invalid *Value.Value type: Kind="ptr", Type=T"string"`,
+	})
+
+	v5 := []int{}
+	result = append(result, fromValueTest{
+		in: &cycleparser.Value{
+			Refid: 1,
+			Kind:  cycleparser.Ptr,
+			Value: &cycleparser.Value{
+				Refid: 2,
+				Kind:  cycleparser.Slice,
+				Value: []any{1},
+			},
+		},
+		out: &v5,
+		err: `cycleparser.FromValue: cycleparser.Resolver: invalid *Value.Value type: Kind="slice", Type=T"int"`,
+	})
+
+	v6 := []int{}
+	result = append(result, fromValueTest{
+		in: &cycleparser.Value{
+			Refid: 1,
+			Kind:  cycleparser.Ptr,
+			Value: &cycleparser.Value{
+				Refid: 2,
+				Kind:  cycleparser.Slice,
+				Value: []any{
+					&cycleparser.Value{Refid: 3, Kind: cycleparser.Int, Value: "invalid"},
+				},
+			},
+		},
+		out: &v6,
+		err: `cycleparser.Value: invalid value string("invalid") for kind "int"`,
+	})
+
+	v7 := 0
+	result = append(result, fromValueTest{
+		in: &cycleparser.Value{
+			Refid: 1,
+			Kind:  cycleparser.Ptr,
+			Value: &cycleparser.Value{
+				Refid: 2,
+				Kind:  cycleparser.Slice,
+				Value: []any{},
+			},
+		},
+		out: &v7,
+		err: `cycleparser.FromValue: unexpected kind (expected: slice, got: int)`,
+	})
+
 	return result
 }
 
@@ -7,12 +7,13 @@ const (
 
 	Bool = "bool"
 
-	Int       = "int"
-	Int8      = "int8"
-	Int16     = "int16"
-	Int32     = "int32"
-	Int64     = "int64"
-	TaskState = "TaskState"
+	Int          = "int"
+	Int8         = "int8"
+	Int16        = "int16"
+	Int32        = "int32"
+	Int64        = "int64"
+	TaskState    = "TaskState"
+	TaskPriority = "TaskPriority"
 
 	Uint   = "uint"
 	Uint8  = "uint8"
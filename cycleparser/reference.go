@@ -70,7 +70,14 @@ func (r *Resolver) resolvePtr(v *Value) error {
 			Kind:  Ptr,
 		}
 	}
-	iv := v.Value.(*Value)
+	iv, ok := v.Value.(*Value)
+	if !ok {
+		return &ResolverError{
+			Value: v,
+			Type:  fmt.Sprintf("%T", v.Value),
+			Kind:  Ptr,
+		}
+	}
 	return r.resolve(iv)
 }
 
@@ -78,7 +85,14 @@ func (r *Resolver) resolveWIthSubvalues(v *Value) error {
 	switch val := v.Value.(type) {
 	case map[string]any:
 		for _, mv := range val {
-			iv := mv.(*Value)
+			iv, ok := mv.(*Value)
+			if !ok {
+				return &ResolverError{
+					Value: v,
+					Type:  fmt.Sprintf("%T", mv),
+					Kind:  v.Kind,
+				}
+			}
 			if err := r.resolve(iv); err != nil {
 				return err
 			}
@@ -92,7 +106,14 @@ func (r *Resolver) resolveWIthSubvalues(v *Value) error {
 		}
 	case []any:
 		for _, mv := range val {
-			iv := mv.(*Value)
+			iv, ok := mv.(*Value)
+			if !ok {
+				return &ResolverError{
+					Value: v,
+					Type:  fmt.Sprintf("%T", mv),
+					Kind:  v.Kind,
+				}
+			}
 			if err := r.resolve(iv); err != nil {
 				return err
 			}
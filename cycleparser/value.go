@@ -36,9 +36,17 @@ func fixPtr(kind string, v any) (any, error) {
 	if !ok {
 		return nil, &InvalidValueError{Kind: kind, Value: v}
 	}
+	refid, ok := m["refid"].(float64)
+	if !ok {
+		return nil, &InvalidValueError{Kind: kind, Value: v}
+	}
+	innerKind, ok := m["kind"].(string)
+	if !ok {
+		return nil, &InvalidValueError{Kind: kind, Value: v}
+	}
 	iv := &Value{
-		Refid: uint64(m["refid"].(float64)),
-		Kind:  m["kind"].(string),
+		Refid: uint64(refid),
+		Kind:  innerKind,
 	}
 	if m["value"] == nil {
 		return iv, nil
@@ -81,37 +89,49 @@ func fixSlice(kind string, v any) (any, error) {
 	return m, nil
 }
 
-// fixTypes recursively fixes field types after json.Unmarshal
-//
-//nolint:gocyclo // go lacks generics and as such there is no further way to optimize it
-func fixTypes(kind string, v any) (res any, err error) {
+// fixNumber converts a json number (always a float64 after json.Unmarshal) to the type of its kind
+func fixNumber(kind string, v any) (any, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return nil, &InvalidValueError{Kind: kind, Value: v}
+	}
 	switch kind {
-	case String, Bool:
-		return v, nil
-	case Ref, Int, TaskState:
-		return int(v.(float64)), nil
 	case Int8:
-		return int8(v.(float64)), nil
+		return int8(f), nil
 	case Int16:
-		return int16(v.(float64)), nil
+		return int16(f), nil
 	case Int32:
-		return int32(v.(float64)), nil
+		return int32(f), nil
 	case Int64:
-		return int64(v.(float64)), nil
+		return int64(f), nil
 	case Uint:
-		return uint(v.(float64)), nil
+		return uint(f), nil
 	case Uint8:
-		return uint8(v.(float64)), nil
+		return uint8(f), nil
 	case Uint16:
-		return uint16(v.(float64)), nil
+		return uint16(f), nil
 	case Uint32:
-		return uint32(v.(float64)), nil
+		return uint32(f), nil
 	case Uint64:
-		return uint64(v.(float64)), nil
+		return uint64(f), nil
 	case Float32:
-		return float32(v.(float64)), nil
+		return float32(f), nil
 	case Float64:
-		return v.(float64), nil
+		return f, nil
+	}
+	return int(f), nil
+}
+
+// fixTypes recursively fixes field types after json.Unmarshal
+//
+//nolint:gocyclo // go lacks generics and as such there is no further way to optimize it
+func fixTypes(kind string, v any) (res any, err error) {
+	switch kind {
+	case String, Bool:
+		return v, nil
+	case Ref, Int, TaskState, TaskPriority, Int8, Int16, Int32, Int64,
+		Uint, Uint8, Uint16, Uint32, Uint64, Float32, Float64:
+		return fixNumber(kind, v)
 	case Ptr:
 		return fixPtr(kind, v)
 	case Struct, Map:
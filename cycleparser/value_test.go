@@ -479,6 +479,35 @@ func unmarshalJSONTests() []unmarshalJSONTest {
 		}`,
 		err: &InvalidValueError{Kind: Slice, Value: "invalid"},
 	})
+	res = append(res, unmarshalJSONTest{
+		in: `{
+			"refid": 1,
+			"kind": "int",
+			"value": "invalid"
+		}`,
+		err: &InvalidValueError{Kind: Int, Value: "invalid"},
+	})
+	res = append(res, unmarshalJSONTest{
+		in: `{
+			"refid": 1,
+			"kind": "ptr",
+			"value": {
+				"kind": "struct"
+			}
+		}`,
+		err: &InvalidValueError{Kind: Ptr, Value: map[string]any{"kind": "struct"}},
+	})
+	res = append(res, unmarshalJSONTest{
+		in: `{
+			"refid": 1,
+			"kind": "ptr",
+			"value": {
+				"refid": 2,
+				"value": 1
+			}
+		}`,
+		err: &InvalidValueError{Kind: Ptr, Value: map[string]any{"refid": float64(2), "value": float64(1)}},
+	})
 
 	return res[0:len(res):len(res)]
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/marcos-venicius/daily-term/taskmanagement"
+)
+
+// the board of the current day, even while a past day is open
+func (editor *Editor) currentDayBoard() *taskmanagement.Board {
+	if editor.liveBoard != nil {
+		return editor.liveBoard
+	}
+
+	return editor.board
+}
+
+// opens the board of the given day (YYYY-MM-DD or today), past days are read-only
+func (editor *Editor) openDay(value string) {
+	live := editor.currentDayBoard()
+
+	board, err := live.OpenDay(value)
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	if board == live {
+		editor.openToday()
+		return
+	}
+
+	editor.liveBoard = live
+	editor.board = board
+	editor.historyTaskId = followSelectedTask
+
+	editor.SetInfoMessage(fmt.Sprintf("showing %v (read-only), use :today to go back", board.Day()))
+}
+
+func (editor *Editor) openPreviousDay() {
+	date, err := editor.currentDayBoard().PreviousDay()
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	editor.openDay(date)
+}
+
+func (editor *Editor) openToday() {
+	if editor.liveBoard == nil {
+		editor.SetInfoMessage("you are already on today's board")
+		return
+	}
+
+	editor.board = editor.liveBoard
+	editor.liveBoard = nil
+	editor.historyTaskId = followSelectedTask
+}
+
+// tells (with an error message) when the open board is a past day
+func (editor *Editor) refuseReadOnly() bool {
+	if editor.board.IsReadOnly() {
+		editor.SetErrorMessage("Past days are read-only, use :today to go back")
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marcos-venicius/daily-term/taskmanagement"
+	"github.com/nsf/termbox-go"
+)
+
+// the details pane is drawn beside the task list when the terminal is at least this wide, otherwise below it
+const detailsSideBySideMinWidth = 80
+
+// kinds of pane that can be shown next to the task list
+type SidePane int
+
+const (
+	NoPane SidePane = iota
+	DetailsPane
+	HistoryPane
+)
+
+// the history pane follows the selected task when no task id is pinned
+const followSelectedTask = -1
+
+func (editor *Editor) ToggleDetails() {
+	editor.togglePane(DetailsPane)
+}
+
+// shows the history of the selected task, or hides it when it is already visible
+func (editor *Editor) ToggleHistory() {
+	if editor.pane == HistoryPane && editor.historyTaskId != followSelectedTask {
+		editor.historyTaskId = followSelectedTask
+		return
+	}
+
+	editor.historyTaskId = followSelectedTask
+	editor.togglePane(HistoryPane)
+}
+
+// shows the history of the task with the given id, even if it was deleted already
+func (editor *Editor) ShowHistoryOf(id int) {
+	editor.historyTaskId = id
+	editor.pane = HistoryPane
+}
+
+func (editor *Editor) togglePane(pane SidePane) {
+	if editor.pane == pane {
+		editor.pane = NoPane
+	} else {
+		editor.pane = pane
+	}
+}
+
+func (editor *Editor) CloseDetails() {
+	editor.pane = NoPane
+}
+
+func (editor *Editor) detailsBeside() bool {
+	return editor.width >= detailsSideBySideMinWidth
+}
+
+// width available to draw the task list
+func (editor *Editor) taskListWidth() int {
+	if editor.pane != NoPane && editor.detailsBeside() {
+		return editor.width/2 - 1
+	}
+
+	return editor.width
+}
+
+func (editor *Editor) detailsLines(width int) []string {
+	task := editor.board.CurrentTask()
+
+	if task == nil {
+		return []string{"You have no selected task"}
+	}
+
+	var lines []string
+
+	lines = append(lines, wrapText(fmt.Sprintf("[%04d] %v", task.Id, task.Name), width)...)
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("State: %v", editor.board.Workflow().Name(task.State)))
+	lines = append(lines, fmt.Sprintf("Priority: %v", task.Priority))
+
+	if task.HasDueDate() {
+		lines = append(lines, fmt.Sprintf("Due: %v", task.DueDate))
+	}
+
+	if estimate := task.Estimate(); !estimate.IsZero() {
+		lines = append(lines, fmt.Sprintf("Estimate: %v", estimate))
+	}
+
+	if spent := task.TimeSpent(time.Now()); spent > 0 {
+		running := ""
+
+		if task.IsTracking() {
+			running = " (running)"
+		}
+
+		lines = append(lines, fmt.Sprintf("Time spent: %v%v", taskmanagement.FormatDuration(spent, true), running))
+	}
+
+	if task.IsRecurring() {
+		lines = append(lines, fmt.Sprintf("Repeats: %v", taskmanagement.DescribeRecurrence(task.Recurrence)))
+	}
+
+	for _, blocker := range editor.board.OpenBlockers(task) {
+		lines = append(lines, wrapText(fmt.Sprintf("Blocked by: [%04d] %v", blocker.Id, blocker.Name), width)...)
+	}
+
+	if len(task.Tags) > 0 {
+		lines = append(lines, wrapText(fmt.Sprintf("Tags: #%v", strings.Join(task.Tags, " #")), width)...)
+	}
+
+	if subtasks := task.Subtasks(); len(subtasks) > 0 {
+		completed := 0
+
+		for _, subtask := range subtasks {
+			if editor.board.IsTaskDone(subtask) {
+				completed++
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("Subtasks: %d/%d completed", completed, len(subtasks)))
+	}
+
+	lines = append(lines, "", "Notes:")
+
+	if task.Notes == "" {
+		lines = append(lines, "(no notes)")
+	} else {
+		lines = append(lines, wrapText(task.Notes, width)...)
+	}
+
+	return lines
+}
+
+func (editor *Editor) historyLines(width int) []string {
+	id := editor.historyTaskId
+
+	if id == followSelectedTask {
+		task := editor.board.CurrentTask()
+
+		if task == nil {
+			return []string{"You have no selected task"}
+		}
+
+		id = task.Id
+	}
+
+	lines := []string{fmt.Sprintf("[%04d] History", id), ""}
+
+	entries := editor.board.TaskHistory(id)
+
+	if len(entries) == 0 {
+		return append(lines, "(no history)")
+	}
+
+	for _, entry := range entries {
+		at := time.Unix(entry.At, 0).Format("2006-01-02 15:04")
+
+		lines = append(lines, wrapText(fmt.Sprintf("%v %v", at, entry.Describe()), width)...)
+	}
+
+	return lines
+}
+
+func (editor *Editor) DisplayDetails() {
+	if editor.pane == NoPane {
+		return
+	}
+
+	const startingRow = 2
+
+	// the last rows are used by the messages and the command input
+	lastRow := editor.height - 3
+
+	x, y, width := 0, startingRow, editor.width
+
+	if editor.detailsBeside() {
+		x = editor.width/2 + 1
+		width = editor.width - x
+
+		fill(x-1, y, 1, lastRow-y, termbox.Cell{Ch: '│', Fg: termbox.ColorWhite})
+	} else {
+		y += len(editor.board.VisibleTasks()) + 1
+
+		fill(x, y, width, 1, termbox.Cell{Ch: '─', Fg: termbox.ColorWhite})
+
+		y++
+	}
+
+	lines := editor.detailsLines(width)
+
+	if editor.pane == HistoryPane {
+		lines = editor.historyLines(width)
+	}
+
+	for index, line := range lines {
+		if y+index >= lastRow {
+			break
+		}
+
+		color := termbox.ColorWhite
+
+		if index == 0 {
+			color = termbox.ColorBlue
+		}
+
+		tbprintn(x, y+index, width, color, termbox.ColorDefault, line)
+	}
+}
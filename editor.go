@@ -1,10 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/marcos-venicius/daily-term/argumentparser"
 	"github.com/marcos-venicius/daily-term/taskmanagement"
+	"github.com/mattn/go-runewidth"
 	"github.com/nsf/termbox-go"
 )
 
@@ -13,23 +17,35 @@ const (
 	NormalMode  EditorMode = iota // when the user wants to be able to select another editor mode
 	CommandMode EditorMode = iota // when the user wants execute some command like quit (q)
 	DeleteMode  EditorMode = iota // when the user wants delete a task
+	ArchiveMode EditorMode = iota // when the user is browsing the archived tasks
+	SearchMode  EditorMode = iota // when the user is typing a search
 )
 
 type EditorMode int
 
 type Editor struct {
-	mode           EditorMode // current editor mode (default is Normal Mode)
-	termbox_event  chan termbox.Event
-	running        bool
-	commandInput   *Input
-	argumentParser *argumentparser.ArgumentParser
-	errorMessage   string
-	infoMessage    string
-	width          int
-	height         int
-	board          *taskmanagement.Board
-	fps            float64
-	repository     *taskmanagement.Repository
+	mode               EditorMode // current editor mode (default is Normal Mode)
+	termbox_event      chan termbox.Event
+	running            bool
+	commandInput       *Input
+	argumentParser     *argumentparser.ArgumentParser
+	errorMessage       string
+	infoMessage        string
+	width              int
+	height             int
+	board              *taskmanagement.Board // active board
+	liveBoard          *taskmanagement.Board // board of the current day while a past day is open, nil otherwise
+	workflow           *taskmanagement.Workflow
+	fps                float64
+	repository         *taskmanagement.Repository
+	pane               SidePane // pane shown next to the task list
+	historyTaskId      int      // task shown in the history pane (followSelectedTask by default)
+	archive            *taskmanagement.Archive
+	archiveSelection   int    // index of the selected archived task in ARCHIVE mode
+	searchQuery        string // highlighted in the task list and used by n and N
+	searchHistory      []string
+	searchHistoryIndex int    // search shown by the search prompt while browsing the history
+	banner             string // problem with the database, shown until it is dismissed with Esc
 }
 
 func CreateEditor(repository *taskmanagement.Repository) *Editor {
@@ -40,9 +56,15 @@ func CreateEditor(repository *taskmanagement.Repository) *Editor {
 	termbox.SetInputMode(termbox.InputEsc)
 
 	argumentParser := argumentparser.CreateArgumentParser()
-	board := taskmanagement.CreateBoard()
 
-	repository.LoadBoard(board)
+	// a corrupt database is replaced by a backup before anything is read from it
+	recoveryErr := repository.RecoverDatabase()
+
+	workflow, workflowErr := repository.LoadWorkflow()
+
+	archive, archiveErr := repository.LoadArchive()
+
+	boardName, boardErr := repository.ActiveBoardName()
 
 	editor := &Editor{
 		mode:           NormalMode,
@@ -50,11 +72,32 @@ func CreateEditor(repository *taskmanagement.Repository) *Editor {
 		running:        true,
 		commandInput:   CreateInput(windowWidth, 1, 0, windowHeight-1),
 		argumentParser: argumentParser,
-		board:          board,
+		workflow:       workflow,
 		width:          windowWidth,
 		height:         windowHeight,
 		fps:            50,
 		repository:     repository,
+		archive:        archive,
+		historyTaskId:  followSelectedTask,
+	}
+
+	editor.showBanner(recoveryErr)
+
+	if !editor.setErrorMessageIfNNil(workflowErr) {
+		editor.checkStateKeys()
+	}
+
+	editor.setErrorMessageIfNNil(boardErr)
+	editor.openBoard(boardName)
+
+	// a corrupt archive was moved aside, the empty archive can be used
+	if _, corrupt := archiveErr.(*taskmanagement.CorruptDatabaseError); corrupt {
+		editor.showBanner(archiveErr)
+		archiveErr = nil
+	}
+
+	if !editor.setErrorMessageIfNNil(archiveErr) {
+		editor.archiveExpiredTasks()
 	}
 
 	go func() {
@@ -87,6 +130,17 @@ func (editor *Editor) SetCommandMode() {
 	editor.mode = CommandMode
 }
 
+// enters the command mode with the command input filled with the given command
+// and the cursor placed "cursorOffset" runes before its end
+func (editor *Editor) OpenCommand(command string, cursorOffset int) {
+	editor.SetCommandMode()
+	editor.commandInput.SetText(":" + command)
+
+	for i := 0; i < cursorOffset; i++ {
+		editor.commandInput.MoveCursorOneRuneBackward()
+	}
+}
+
 func (mode *EditorMode) IsDelete() bool {
 	return *mode == DeleteMode
 }
@@ -110,40 +164,181 @@ func (mode *EditorMode) Display() {
 	case DeleteMode:
 		tbprint(0, 0, termbox.ColorRed, termbox.ColorDefault, "DELETE")
 		break
+	case ArchiveMode:
+		tbprint(0, 0, termbox.ColorBlue, termbox.ColorDefault, "ARCHIVE")
+		break
+	case SearchMode:
+		tbprint(0, 0, termbox.ColorWhite, termbox.ColorDefault, "SEARCH")
+		break
 	default:
 		tbprint(0, 0, termbox.ColorWhite, termbox.ColorDefault, "UNKNOWN")
 		break
 	}
 }
 
+// "0012, 0034"
+func blockerIds(blockers []taskmanagement.Task) string {
+	var ids []string
+
+	for _, blocker := range blockers {
+		ids = append(ids, fmt.Sprintf("%04d", blocker.Id))
+	}
+
+	return strings.Join(ids, ", ")
+}
+
 func (editor *Editor) DisplayTasks() {
 	const startingRow = 2
 
-	for row, task := range editor.board.Tasks() {
+	if editor.mode.IsArchive() {
+		editor.DisplayArchive()
+		return
+	}
+
+	now := time.Now()
+
+	selectedTaskId := -1
+
+	if id := editor.board.SelectedTaskId(); id != nil {
+		selectedTaskId = *id
+	}
+
+	for row, task := range editor.board.VisibleTasks() {
 		color := termbox.ColorWhite
+		suffix := ""
+
+		if state, ok := editor.board.Workflow().State(task.State); ok {
+			color = stateColor(state.Color)
+		}
+
+		done := editor.board.IsTaskDone(&task)
+
+		if !done && task.IsOverdue(now) {
+			color = termbox.ColorRed
+			suffix = " (overdue)"
+		} else if !done && task.IsDueToday(now) {
+			color = termbox.ColorCyan
+			suffix = " (due today)"
+		} else if !done && task.HasDueDate() {
+			suffix = fmt.Sprintf(" (due %v)", task.DueDate)
+		}
+
+		if blockers := editor.board.OpenBlockers(&task); !done && len(blockers) > 0 {
+			color = termbox.ColorDarkGray
+			suffix += fmt.Sprintf(" (blocked by %v)", blockerIds(blockers))
+		}
+
+		if estimate := task.Estimate(); !estimate.IsZero() {
+			suffix += fmt.Sprintf(" ~%v", estimate)
+		}
 
-		switch task.State {
-		case taskmanagement.InProgress:
-			color = termbox.ColorYellow
-			break
-		case taskmanagement.Completed:
-			color = termbox.ColorGreen
-			break
+		if task.IsTracking() {
+			suffix += fmt.Sprintf(" ⏱ %v", taskmanagement.FormatDuration(task.TimeSpent(now), true))
+		} else if spent := task.TimeSpent(now); spent > 0 {
+			suffix += fmt.Sprintf(" [%v]", taskmanagement.FormatDuration(spent, false))
 		}
 
-		selectedSymbol := task.Symbol(*editor.board.SelectedTaskId())
+		selectedSymbol := task.Symbol(selectedTaskId)
 
-		if task.Id == *editor.board.SelectedTaskId() {
+		if task.Id == selectedTaskId {
 			if editor.mode.IsDelete() {
 				selectedSymbol = '-'
 				color = termbox.ColorLightRed
 			}
 		}
 
-		text := fmt.Sprintf("%c [%04d] %v", selectedSymbol, task.Id, task.Name)
+		name := task.Name
+
+		if marker := task.Priority.Marker(); marker != "" {
+			name = fmt.Sprintf("%v %v", marker, name)
+		}
+
+		if task.IsRecurring() {
+			name = fmt.Sprintf("%v ↻", name)
+		}
+
+		for _, tag := range task.Tags {
+			name = fmt.Sprintf("%v #%v", name, tag)
+		}
+
+		if task.HasSubtasks() {
+			if task.Folded {
+				name = fmt.Sprintf("▸ %v (+%d)", name, len(task.Subtasks()))
+			} else {
+				name = fmt.Sprintf("▾ %v", name)
+			}
+		}
+
+		prefix := fmt.Sprintf("%c [%04d] %v", selectedSymbol, task.Id, strings.Repeat("  ", task.Depth()))
+
+		text := fmt.Sprintf("%v%v%v", prefix, name, suffix)
+
+		tbprintn(0, startingRow+row, editor.taskListWidth(), color, termbox.ColorDefault, text)
+
+		if editor.searchQuery != "" && task.NameContains(editor.searchQuery) {
+			editor.highlightSearch(0, startingRow+row, editor.taskListWidth(), text, len(prefix))
+		}
+	}
+}
+
+// shows the sum of the estimates for every state, in the row between the mode indicator and the tasks
+func (editor *Editor) DisplayEstimates() {
+	if editor.mode.IsArchive() {
+		return
+	}
+
+	x := 0
+
+	for index, total := range editor.board.EstimateTotals() {
+		text := fmt.Sprintf("%v: %v", total.State.Name, total.Estimate)
+
+		if index > 0 {
+			text = ", " + text
+		}
+
+		tbprintn(x, 1, editor.width-x, stateColor(total.State.Color), termbox.ColorDefault, text)
+
+		x += runewidth.StringWidth(text)
+
+		if x >= editor.width {
+			return
+		}
+	}
+}
+
+// shows the board status (like active filters) next to the mode indicator
+func (editor *Editor) DisplayStatus() {
+	const startingColumn = 10
+
+	status := []string{fmt.Sprintf("board: %v", editor.board.Name())}
+
+	if editor.board.IsReadOnly() {
+		status = append(status, fmt.Sprintf("day: %v (read-only)", editor.board.Day()))
+	}
+
+	if key := editor.board.SortKey(); key != taskmanagement.SortNone {
+		direction := "asc"
+
+		if editor.board.SortDescending() {
+			direction = "desc"
+		}
+
+		status = append(status, fmt.Sprintf("sort: %v %v", key, direction))
+	}
+
+	if tag := editor.board.TagFilter(); tag != "" {
+		status = append(status, fmt.Sprintf("tag: #%v", tag))
+	}
+
+	if state := editor.board.StateFilter(); state != "" {
+		status = append(status, fmt.Sprintf("state: %v", state))
+	}
 
-		tbprint(0, startingRow+row, color, termbox.ColorDefault, text)
+	if text := editor.board.TextFilter(); text != "" {
+		status = append(status, fmt.Sprintf(`text: "%v"`, text))
 	}
+
+	tbprint(startingColumn, 0, termbox.ColorBlue, termbox.ColorDefault, strings.Join(status, "  "))
 }
 
 func (editor *Editor) DisplayError() {
@@ -154,6 +349,33 @@ func (editor *Editor) DisplayError() {
 	}
 }
 
+// shows the error in the banner after the ones already shown, nil errors are ignored
+func (editor *Editor) showBanner(err error) {
+	if err == nil {
+		return
+	}
+
+	if editor.banner != "" {
+		editor.banner += " | "
+	}
+
+	editor.banner += err.Error()
+}
+
+func (editor *Editor) DisplayBanner() {
+	if editor.banner == "" {
+		return
+	}
+
+	banner := fmt.Sprintf(" %v (Esc to dismiss)", editor.banner)
+
+	for x := 0; x < editor.width; x++ {
+		termbox.SetCell(x, editor.height-3, ' ', termbox.ColorWhite, termbox.ColorRed)
+	}
+
+	tbprintn(0, editor.height-3, editor.width, termbox.ColorWhite, termbox.ColorRed, banner)
+}
+
 func (editor *Editor) DisplayInfo() {
 	if editor.infoMessage != "" && editor.mode.IsNormal() {
 		tbprint(0, editor.height-2, termbox.ColorWhite, termbox.ColorDefault, editor.infoMessage)
@@ -177,9 +399,23 @@ func (editor *Editor) listenNormalModeEvents(event termbox.Event) {
 		editor.infoMessage = ""
 	}
 
+	switch event.Key {
+	case termbox.KeyEnter:
+		editor.ToggleDetails()
+		return
+	case termbox.KeyEsc:
+		editor.CloseDetails()
+		editor.searchQuery = ""
+		editor.banner = ""
+		return
+	case termbox.KeyCtrlR:
+		editor.Redo()
+		return
+	}
+
 	switch event.Ch {
 	case ':':
-		editor.SetCommandMode()
+		editor.OpenCommand("", 0)
 		break
 	case 'd':
 		editor.SetDeleteMode()
@@ -193,20 +429,66 @@ func (editor *Editor) listenNormalModeEvents(event termbox.Event) {
 	case 'k':
 		editor.board.SelectPreviousTask()
 		break
-	case 't':
-		editor.ChangeCurrentTaskStateFor(taskmanagement.Todo)
+	case 'r':
+		editor.OpenRename()
+		break
+	case '/':
+		editor.OpenSearch()
+		break
+	case 'n':
+		editor.JumpToNextMatch(1)
+		break
+	case 'N':
+		editor.JumpToNextMatch(-1)
+		break
+	case 'J':
+		editor.moveCurrentTask(taskmanagement.MoveDown)
+		break
+	case 'K':
+		editor.moveCurrentTask(taskmanagement.MoveUp)
+		break
+	case 'h':
+		editor.FoldOrSelectParent()
+		break
+	case 'l':
+		editor.UnfoldCurrentTask()
+		break
+	case 'a':
+		editor.OpenCommand(`st ""`, 1)
+		break
+	case 'H':
+		editor.ToggleHistory()
 		break
-	case 'i':
-		editor.ChangeCurrentTaskStateFor(taskmanagement.InProgress)
+	case 'u':
+		editor.Undo()
 		break
-	case 'c':
-		editor.ChangeCurrentTaskStateFor(taskmanagement.Completed)
+	case '+':
+		editor.ChangeCurrentTaskPriority(1)
+		break
+	case '-':
+		editor.ChangeCurrentTaskPriority(-1)
 		break
 	default:
+		if state, ok := editor.board.Workflow().StateByKey(event.Ch); ok {
+			editor.ChangeCurrentTaskStateFor(state.Id)
+		}
+
 		break
 	}
 }
 
+// keys used by NORMAL mode, the states defined by the user cannot use them
+const normalModeKeys = ":/nNdqjkJKhlaHru+-"
+
+// warns about state keys that are shadowed by NORMAL mode keys
+func (editor *Editor) checkStateKeys() {
+	for _, state := range editor.workflow.States() {
+		if state.Key != "" && strings.Contains(normalModeKeys, state.Key) {
+			editor.showBanner(errors.New(fmt.Sprintf(`Key "%v" of state "%v" is already used by NORMAL mode, choose another key in %v`, state.Key, state.Name, "states.json")))
+		}
+	}
+}
+
 func (editor *Editor) listenCommandModeEvents(event termbox.Event) {
 	if !editor.running {
 		return
@@ -246,22 +528,43 @@ func (editor *Editor) listenDeleteModeEvents(event termbox.Event) {
 
 func (editor *Editor) listenEvents() {
 	go func() {
+		// the database is checked here so reloads never run in the middle of handling a key
+		ticker := time.NewTicker(databaseCheckInterval)
+
+		defer ticker.Stop()
+
 		for editor.running {
-			event := <-editor.termbox_event
-
-			if editor.mode.IsNormal() {
-				editor.listenNormalModeEvents(event)
-			} else if editor.mode.IsCommand() {
-				editor.listenCommandModeEvents(event)
-			} else if editor.mode.IsDelete() {
-				editor.listenDeleteModeEvents(event)
+			select {
+			case event := <-editor.termbox_event:
+				editor.handleEvent(event)
+			case <-ticker.C:
+				editor.checkDatabase()
 			}
-
-			editor.commandInput.handleEvents(editor, event)
 		}
 	}()
 }
 
+func (editor *Editor) handleEvent(event termbox.Event) {
+	// events that open the command (or search) mode are not typed in the command input
+	wasCommand := editor.mode.IsCommand() || editor.mode.IsSearch()
+
+	if editor.mode.IsNormal() {
+		editor.listenNormalModeEvents(event)
+	} else if editor.mode.IsCommand() {
+		editor.listenCommandModeEvents(event)
+	} else if editor.mode.IsDelete() {
+		editor.listenDeleteModeEvents(event)
+	} else if editor.mode.IsArchive() {
+		editor.listenArchiveModeEvents(event)
+	} else if editor.mode.IsSearch() {
+		editor.listenSearchModeEvents(event)
+	}
+
+	if wasCommand {
+		editor.commandInput.handleEvents(editor, event)
+	}
+}
+
 func (editor *Editor) SetErrorMessage(message string) {
 	editor.infoMessage = ""
 	editor.errorMessage = message
@@ -300,9 +603,111 @@ func (editor *Editor) exec(command string) {
 	case "new task", "nt":
 		editor.addTask(cmd.Arguments)
 		break
+	case "new subtask", "st":
+		editor.addSubtask(cmd.Arguments)
+		break
 	case "delete task", "dt":
 		editor.deleteTask(cmd.Arguments)
 		break
+	case "due":
+		editor.setTaskDueDate(cmd.Arguments)
+		break
+	case "priority":
+		editor.setTaskPriority(cmd.Arguments)
+		break
+	case "priority-order":
+		editor.setPriorityOrder(cmd.Arguments)
+		break
+	case "tag":
+		editor.tagTask(cmd.Arguments)
+		break
+	case "untag":
+		editor.untagTask(cmd.Arguments)
+		break
+	case "tag-filter":
+		editor.setTagFilter(cmd.Arguments)
+		break
+	case "archive":
+		editor.archiveFinishedTasks()
+		break
+	case "auto-archive":
+		editor.setAutoArchive(cmd.Arguments)
+		break
+	case "archived":
+		editor.SetArchiveMode()
+		break
+	case "restore":
+		editor.restoreArchivedTask(cmd.Arguments[0].Value.(int))
+		break
+	case "repeat":
+		editor.setTaskRecurrence(cmd.Arguments)
+		break
+	case "note":
+		editor.addTaskNote(cmd.Arguments)
+		break
+	case "clear-notes":
+		editor.clearTaskNotes(cmd.Arguments)
+		break
+	case "filter":
+		editor.setFilter(cmd.Arguments[0].Value.(string))
+		break
+	case "sort":
+		editor.setSort(cmd.Arguments)
+		break
+	case "estimate":
+		editor.setTaskEstimate(cmd.Arguments)
+		break
+	case "rename":
+		editor.renameTask(cmd.Arguments)
+		break
+	case "move":
+		editor.moveCurrentTask(cmd.Arguments[0].Value.(string))
+		break
+	case "depends":
+		editor.addTaskDependency(cmd.Arguments)
+		break
+	case "undepend":
+		editor.removeTaskDependency(cmd.Arguments)
+		break
+	case "history":
+		editor.showHistory(cmd.Arguments)
+		break
+	case "boards":
+		editor.listBoards()
+		break
+	case "reload":
+		editor.reload()
+		break
+	case "board":
+		editor.switchBoard(cmd.Arguments[0].Value.(string))
+		break
+	case "new-board":
+		editor.newBoard(cmd.Arguments[0].Value.(string))
+		break
+	case "rename-board":
+		editor.renameBoard(cmd.Arguments)
+		break
+	case "delete-board":
+		editor.deleteBoard(cmd.Arguments[0].Value.(string))
+		break
+	case "yesterday":
+		editor.openPreviousDay()
+		break
+	case "day":
+		editor.openDay(cmd.Arguments[0].Value.(string))
+		break
+	case "today":
+		editor.openToday()
+		break
+	case "undo":
+		editor.Undo()
+		break
+	case "redo":
+		editor.Redo()
+		break
+	case "restore-backup":
+		editor.restoreBackup(cmd.Arguments)
+		break
 	default:
 		editor.SetErrorMessage(fmt.Sprintf(`Unhandled command "%v"`, cmd.Name))
 		break
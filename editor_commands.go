@@ -1,6 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/marcos-venicius/daily-term/argumentparser"
 	"github.com/marcos-venicius/daily-term/taskmanagement"
 )
@@ -10,28 +14,33 @@ func (editor *Editor) Quit() {
 }
 
 func (editor *Editor) ChangeCurrentTaskStateFor(state taskmanagement.TaskState) {
-	var err error
+	if editor.board.CurrentTask() == nil {
+		editor.SetErrorMessage("You have no selected task")
+		return
+	}
 
-	previousTaskState := editor.board.CurrentTask().State
-
-	switch state {
-	case taskmanagement.Todo:
-		err = editor.board.MoveCurrentSelectedTaskToTodo()
-		break
-	case taskmanagement.InProgress:
-		err = editor.board.MoveCurrentSelectedTaskToInProgress()
-		break
-	case taskmanagement.Completed:
-		err = editor.board.MoveCurrentSelectedTaskToCompleted()
-		break
-	default:
-		break
+	// the move can select another task when the filters hide the moved one
+	task, err := editor.board.MoveCurrentSelectedTaskTo(state)
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
 	}
 
-	if !editor.setErrorMessageIfNNil(err) {
-		if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
-			editor.setErrorMessageIfNNil(editor.board.SetCustomTaskState(previousTaskState)) // rollback
-		}
+	var nextOccurrence *taskmanagement.Task
+
+	if editor.board.Workflow().IsDone(state) {
+		nextOccurrence, err = editor.board.SpawnNextOccurrence(task.Id, time.Now())
+
+		editor.setErrorMessageIfNNil(err)
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback, it also removes the next occurrence
+		return
+	}
+
+	if nextOccurrence != nil {
+		editor.SetInfoMessage(fmt.Sprintf("next occurrence created for %v", nextOccurrence.DueDate))
 	}
 }
 
@@ -43,7 +52,7 @@ func (editor *Editor) addTask(arguments []argumentparser.CommandArgument) {
 	err := editor.repository.SaveBoard(editor.board)
 
 	if err != nil {
-		editor.board.DeleteCurrentSelectedTask()
+		editor.board.RevertLastChange() // rollback
 
 		editor.SetErrorMessage(err.Error())
 	} else {
@@ -51,34 +60,498 @@ func (editor *Editor) addTask(arguments []argumentparser.CommandArgument) {
 	}
 }
 
+func (editor *Editor) addSubtask(arguments []argumentparser.CommandArgument) {
+	var name = arguments[0].Value.(string)
+
+	_, err := editor.board.AddSubtask(name)
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("new subtask added successfully")
+}
+
 func (editor *Editor) deleteTask(arguments []argumentparser.CommandArgument) {
-	success := false
+	var err error
+
+	if len(arguments) == 0 {
+		err = editor.board.DeleteCurrentSelectedTask()
+	} else {
+		err = editor.board.DeleteTaskById(arguments[0].Value.(int))
+	}
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("task deleted successfully (press u to undo)")
+}
+
+func (editor *Editor) setTaskDueDate(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+	date := arguments[1].Value.(string)
+
+	_, err := editor.board.SetTaskDueDate(taskId, date)
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("due date updated successfully")
+}
+
+func (editor *Editor) ChangeCurrentTaskPriority(delta int) {
+	task := editor.board.CurrentTask()
+
+	if task == nil {
+		editor.SetErrorMessage("You have no selected task")
+		return
+	}
+
+	var err error
+
+	if delta > 0 {
+		err = editor.board.IncreaseCurrentTaskPriority()
+	} else {
+		err = editor.board.DecreaseCurrentTaskPriority()
+	}
+
+	if !editor.setErrorMessageIfNNil(err) {
+		if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+			editor.board.RevertLastChange() // rollback
+		}
+	}
+}
+
+func (editor *Editor) setTaskPriority(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+
+	priority, err := taskmanagement.ParseTaskPriority(arguments[1].Value.(string))
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	_, err = editor.board.SetTaskPriority(taskId, priority)
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("priority updated successfully")
+}
+
+// without arguments it toggles the option
+func (editor *Editor) setPriorityOrder(arguments []argumentparser.CommandArgument) {
+	enabled := !editor.board.PriorityOrder()
+
+	if len(arguments) > 0 {
+		enabled = arguments[0].Value.(bool)
+	}
+
+	editor.board.SetPriorityOrder(enabled)
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	if enabled {
+		editor.SetInfoMessage("tasks are now ordered by priority")
+	} else {
+		editor.SetInfoMessage("tasks are no longer ordered by priority")
+	}
+}
+
+func (editor *Editor) tagTask(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+	tag := arguments[1].Value.(string)
+
+	if editor.setErrorMessageIfNNil(editor.board.AddTaskTag(taskId, tag)) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("tag added successfully")
+}
+
+func (editor *Editor) untagTask(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+	tag := arguments[1].Value.(string)
+
+	if editor.setErrorMessageIfNNil(editor.board.RemoveTaskTag(taskId, tag)) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("tag removed successfully")
+}
+
+// without arguments it removes the current tag filter
+func (editor *Editor) setTagFilter(arguments []argumentparser.CommandArgument) {
+	tag := ""
+
+	if len(arguments) > 0 {
+		tag = arguments[0].Value.(string)
+	}
+
+	if editor.setErrorMessageIfNNil(editor.board.SetTagFilter(tag)) {
+		return
+	}
+
+	if tag == "" {
+		editor.SetInfoMessage("tag filter removed")
+	} else {
+		editor.SetInfoMessage(fmt.Sprintf("showing only tasks tagged #%v", editor.board.TagFilter()))
+	}
+}
+
+// a literal "\n" in the note breaks it in multiple lines
+func (editor *Editor) addTaskNote(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+	note := strings.ReplaceAll(arguments[1].Value.(string), `\n`, "\n")
+
+	_, err := editor.board.AppendTaskNote(taskId, note)
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("note added successfully")
+}
+
+func (editor *Editor) clearTaskNotes(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+
+	_, err := editor.board.SetTaskNotes(taskId, "")
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
 
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("notes cleared successfully")
+}
+
+// folds the selected task, when it has no subtasks or is already folded, selects its parent
+func (editor *Editor) FoldOrSelectParent() {
+	task := editor.board.CurrentTask()
+
+	if task == nil {
+		editor.SetErrorMessage("You have no selected task")
+		return
+	}
+
+	if !task.HasSubtasks() || task.Folded {
+		editor.setErrorMessageIfNNil(editor.board.SelectParentTask())
+		return
+	}
+
+	if !editor.setErrorMessageIfNNil(editor.board.FoldCurrentTask()) {
+		editor.saveViewChange()
+	}
+}
+
+func (editor *Editor) UnfoldCurrentTask() {
+	if !editor.setErrorMessageIfNNil(editor.board.UnfoldCurrentTask()) {
+		editor.saveViewChange()
+	}
+}
+
+// folding and sorting only change how a board is seen, so past days can use them without being saved.
+// tells if the board could not be saved
+func (editor *Editor) saveViewChange() bool {
+	if editor.board.IsReadOnly() {
+		return false
+	}
+
+	return editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board))
+}
+
+func (editor *Editor) setTaskRecurrence(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+	recurrence := arguments[1].Value.(string)
+
+	_, err := editor.board.SetTaskRecurrence(taskId, recurrence, time.Now())
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("recurrence updated successfully")
+}
+
+// without arguments it disables the automatic archive
+func (editor *Editor) setAutoArchive(arguments []argumentparser.CommandArgument) {
+	days := 0
+
+	if len(arguments) > 0 {
+		days = arguments[0].Value.(int)
+	}
+
+	previousDays := editor.board.AutoArchive()
+
+	if editor.setErrorMessageIfNNil(editor.board.SetAutoArchive(days)) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.SetAutoArchive(previousDays) // rollback
+		return
+	}
+
+	if days == 0 {
+		editor.SetInfoMessage("automatic archive disabled")
+		return
+	}
+
+	editor.SetInfoMessage(fmt.Sprintf("tasks finished more than %d day(s) ago are archived automatically", days))
+	editor.archiveExpiredTasks()
+}
+
+func (editor *Editor) showHistory(arguments []argumentparser.CommandArgument) {
 	if len(arguments) == 0 {
-		err := editor.board.DeleteCurrentSelectedTask()
+		editor.ToggleHistory()
+		return
+	}
+
+	editor.ShowHistoryOf(arguments[0].Value.(int))
+}
+
+func (editor *Editor) Undo() {
+	if editor.setErrorMessageIfNNil(editor.board.Undo()) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.Redo() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("change undone")
+}
+
+func (editor *Editor) Redo() {
+	if editor.setErrorMessageIfNNil(editor.board.Redo()) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.Undo() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("change redone")
+}
+
+func (editor *Editor) addTaskDependency(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+	blockerId := arguments[1].Value.(int)
+
+	if editor.setErrorMessageIfNNil(editor.board.AddTaskDependency(taskId, blockerId)) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage(fmt.Sprintf("task %04d is now blocked by task %04d", taskId, blockerId))
+}
+
+func (editor *Editor) removeTaskDependency(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+	blockerId := arguments[1].Value.(int)
+
+	if editor.setErrorMessageIfNNil(editor.board.RemoveTaskDependency(taskId, blockerId)) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage(fmt.Sprintf("task %04d is no longer blocked by task %04d", taskId, blockerId))
+}
+
+func (editor *Editor) moveCurrentTask(direction string) {
+	if editor.setErrorMessageIfNNil(editor.board.MoveCurrentTask(direction)) {
+		return
+	}
 
-		if err == nil {
-			success = true
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+	}
+}
 
-			editor.SetInfoMessage("task deleted successfully")
-		} else {
-			editor.SetErrorMessage(err.Error())
+// the direction is ascending when not given
+func (editor *Editor) setSort(arguments []argumentparser.CommandArgument) {
+	key := arguments[0].Value.(string)
+	descending := false
+
+	if key == "none" {
+		key = taskmanagement.SortNone
+	}
+
+	if len(arguments) > 1 {
+		switch arguments[1].Value.(string) {
+		case "asc":
+			break
+		case "desc":
+			descending = true
+			break
+		default:
+			editor.SetErrorMessage(fmt.Sprintf(`Invalid direction "%v", expected asc or desc`, arguments[1].Value.(string)))
+			return
 		}
+	}
+
+	previousKey, previousDescending := editor.board.SortKey(), editor.board.SortDescending()
+
+	if editor.setErrorMessageIfNNil(editor.board.SetSort(key, descending)) {
+		return
+	}
+
+	if editor.saveViewChange() {
+		editor.board.SetSort(previousKey, previousDescending) // rollback
+		return
+	}
+
+	if key == taskmanagement.SortNone {
+		editor.SetInfoMessage("tasks are shown in the board order")
 	} else {
-		taskId := arguments[0].Value.(int)
+		editor.SetInfoMessage(fmt.Sprintf("tasks sorted by %v", key))
+	}
+}
 
-		err := editor.board.DeleteTaskById(taskId)
+// the filter is "<kind>=<value>", an empty value removes that filter and "clear" removes all of them
+func (editor *Editor) setFilter(filter string) {
+	if filter == "clear" {
+		editor.board.ClearFilters()
+		editor.SetInfoMessage("filters removed")
+		return
+	}
+
+	kind, value, ok := strings.Cut(filter, "=")
 
-		if err == nil {
-			success = true
+	if !ok {
+		editor.SetErrorMessage(fmt.Sprintf(`Invalid filter "%v", expected state=<state>, text=<text>, tag=<tag> or clear`, filter))
+		return
+	}
 
-			editor.SetInfoMessage("task deleted successfully")
-		} else {
-			editor.SetErrorMessage(err.Error())
+	switch kind {
+	case "state":
+		if editor.setErrorMessageIfNNil(editor.board.SetStateFilter(value)) {
+			return
+		}
+	case "text":
+		editor.board.SetTextFilter(value)
+	case "tag":
+		if editor.setErrorMessageIfNNil(editor.board.SetTagFilter(value)) {
+			return
 		}
+	default:
+		editor.SetErrorMessage(fmt.Sprintf(`Unknown filter "%v", expected state, text or tag`, kind))
+		return
+	}
+
+	if !editor.board.HasTasks() || editor.board.CurrentTask() != nil {
+		return
+	}
+
+	editor.SetInfoMessage("no task matches the filters")
+}
+
+// opens the command input with the rename command of the selected task, filled with its current name
+func (editor *Editor) OpenRename() {
+	task := editor.board.CurrentTask()
+
+	if task == nil {
+		editor.SetErrorMessage("You have no selected task")
+		return
+	}
+
+	editor.OpenCommand(fmt.Sprintf(`rename %d "%v"`, task.Id, argumentparser.EscapeQuoted(task.Name)), 1)
+}
+
+func (editor *Editor) renameTask(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+	name := arguments[1].Value.(string)
+
+	if _, err := editor.board.RenameTask(taskId, name); editor.setErrorMessageIfNNil(err) {
+		return
+	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("task renamed successfully")
+}
+
+func (editor *Editor) setTaskEstimate(arguments []argumentparser.CommandArgument) {
+	taskId := arguments[0].Value.(int)
+
+	estimate, err := taskmanagement.ParseEstimate(arguments[1].Value.(string))
+
+	if editor.setErrorMessageIfNNil(err) {
+		return
 	}
 
-	if success {
-		editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board))
+	if _, err := editor.board.SetTaskEstimate(taskId, estimate); editor.setErrorMessageIfNNil(err) {
+		return
 	}
+
+	if editor.setErrorMessageIfNNil(editor.repository.SaveBoard(editor.board)) {
+		editor.board.RevertLastChange() // rollback
+		return
+	}
+
+	editor.SetInfoMessage("estimate updated successfully")
 }
@@ -164,6 +164,13 @@ func (input *Input) Draw() {
 	termbox.SetCursor(input.x+input.CursorX(), input.y)
 }
 
+// replaces the current text and moves the cursor to its end
+func (input *Input) SetText(text string) {
+	input.text = []byte(text)
+	input.line_voffset = 0
+	input.MoveCursorToEndOfTheLine()
+}
+
 func (input *Input) Reset() {
 	input.text = []byte{}
 	input.line_voffset = 0
@@ -175,7 +182,7 @@ func (input *Input) Reset() {
 }
 
 func (input *Input) handleEvents(editor *Editor, event termbox.Event) {
-	if !editor.mode.IsCommand() || !editor.running {
+	if !(editor.mode.IsCommand() || editor.mode.IsSearch()) || !editor.running {
 		return
 	}
 
@@ -202,11 +209,25 @@ func (input *Input) handleEvents(editor *Editor, event termbox.Event) {
 			input.MoveCursorToBeginningOfTheLine()
 		case termbox.KeyEnd, termbox.KeyCtrlE:
 			input.MoveCursorToEndOfTheLine()
+		case termbox.KeyArrowUp:
+			if editor.mode.IsSearch() {
+				editor.browseSearchHistory(-1)
+			}
+		case termbox.KeyArrowDown:
+			if editor.mode.IsSearch() {
+				editor.browseSearchHistory(1)
+			}
 		case termbox.KeyEnter:
 			termbox.Interrupt()
 			command := input.GetValue()
 			input.Reset()
-			editor.exec(command)
+
+			if editor.mode.IsSearch() {
+				editor.submitSearch(command)
+			} else {
+				editor.exec(command)
+			}
+
 			return
 		default:
 			if event.Ch != 0 {
@@ -215,6 +236,11 @@ func (input *Input) handleEvents(editor *Editor, event termbox.Event) {
 		}
 	}
 
+	// the matches are highlighted while the search is typed
+	if editor.mode.IsSearch() {
+		editor.searchQuery = input.GetValue()
+	}
+
 	if len(input.text) == 0 {
 		termbox.Interrupt()
 		input.Reset()
@@ -9,15 +9,14 @@ import (
 )
 
 func main() {
-	err := termbox.Init()
+	// created before the terminal is taken over, so its errors are readable
+	repository, err := taskmanagement.CreateRepository()
 
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	repository, err := taskmanagement.CreateRepository()
-
-	if err != nil {
+	if err = termbox.Init(); err != nil {
 		log.Fatal(err)
 	}
 
@@ -35,10 +34,14 @@ func main() {
 		update := time.Now()
 
 		editor.mode.Display()
+		editor.DisplayStatus()
+		editor.DisplayEstimates()
 
 		editor.DisplayTasks()
+		editor.DisplayDetails()
+		editor.DisplayBanner()
 
-		if editor.mode.IsCommand() {
+		if editor.mode.IsCommand() || editor.mode.IsSearch() {
 			editor.commandInput.Draw()
 		}
 
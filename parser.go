@@ -19,14 +19,280 @@ func (editor *Editor) InitParser() {
 		},
 	}
 
+	dueArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+		{
+			Name:     "Due date (YYYY-MM-DD|today|tomorrow|none)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	priorityArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+		{
+			Name:     "Priority (none|low|medium|high|urgent)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	priorityOrderArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Enabled (bool)",
+			Required: false,
+			Type:     argumentparser.BooleanArgumentType,
+		},
+	}
+
+	tagArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+		{
+			Name:     "Tag (string)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	dependsArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+		{
+			Name:     "Blocker task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+	}
+
+	tagFilterArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Tag (string)",
+			Required: false,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	noteArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+		{
+			Name:     "Note (string)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	clearNotesArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+	}
+
+	repeatArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+		{
+			Name:     "Recurrence (daily|weekdays|<weekday>|monthly:<day>|none)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	autoArchiveArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Days (int)",
+			Required: false,
+			Type:     argumentparser.IntArgumentType,
+		},
+	}
+
+	restoreArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+	}
+
+	historyArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: false,
+			Type:     argumentparser.IntArgumentType,
+		},
+	}
+
+	boardArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Board name (string)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	renameBoardArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Board name (string)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+		{
+			Name:     "New board name (string)",
+			Required: false,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	dayArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Date (YYYY-MM-DD|today)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	moveArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Direction (up|down|top|bottom)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	sortArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Key (state|id|name|created|none)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+		{
+			Name:     "Direction (asc|desc)",
+			Required: false,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	filterArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Filter (state=<state>|text=<text>|tag=<tag>|clear)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	renameArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+		{
+			Name:     "Task name (string)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	estimateArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Task id (int)",
+			Required: true,
+			Type:     argumentparser.IntArgumentType,
+		},
+		{
+			Name:     "Estimate (5|5pts|2h|1h30m|45m|none)",
+			Required: true,
+			Type:     argumentparser.StringArgumentType,
+		},
+	}
+
+	restoreBackupArguments := []argumentparser.CommandArgumentSyntax{
+		{
+			Name:     "Backup number (int)",
+			Required: false,
+			Type:     argumentparser.IntArgumentType,
+		},
+	}
+
 	editor.argumentParser.AddCommand("q")
 	editor.argumentParser.AddCommand("quit")
 
 	editor.argumentParser.AddCommand("nt", newTaskArguments...)
 	editor.argumentParser.AddCommand("new task", newTaskArguments...)
 
+	editor.argumentParser.AddCommand("st", newTaskArguments...)
+	editor.argumentParser.AddCommand("new subtask", newTaskArguments...)
+
 	editor.argumentParser.AddCommand("dt", deletetaskArguments...)
 	editor.argumentParser.AddCommand("delete task", deletetaskArguments...)
 
+	editor.argumentParser.AddCommand("rename", renameArguments...)
+
+	editor.argumentParser.AddCommand("move", moveArguments...)
+	editor.argumentParser.AddCommand("sort", sortArguments...)
+
+	editor.argumentParser.AddCommand("due", dueArguments...)
+
+	editor.argumentParser.AddCommand("estimate", estimateArguments...)
+
+	editor.argumentParser.AddCommand("priority", priorityArguments...)
+	editor.argumentParser.AddCommand("priority-order", priorityOrderArguments...)
+
+	editor.argumentParser.AddCommand("tag", tagArguments...)
+	editor.argumentParser.AddCommand("untag", tagArguments...)
+	editor.argumentParser.AddCommand("tag-filter", tagFilterArguments...)
+	editor.argumentParser.AddCommand("filter", filterArguments...)
+
+	editor.argumentParser.AddCommand("depends", dependsArguments...)
+	editor.argumentParser.AddCommand("undepend", dependsArguments...)
+
+	editor.argumentParser.AddCommand("repeat", repeatArguments...)
+	editor.argumentParser.AddCommand("note", noteArguments...)
+	editor.argumentParser.AddCommand("clear-notes", clearNotesArguments...)
+	editor.argumentParser.AddCommand("history", historyArguments...)
+
+	editor.argumentParser.AddCommand("boards")
+	editor.argumentParser.AddCommand("reload")
+	editor.argumentParser.AddCommand("board", boardArguments...)
+	editor.argumentParser.AddCommand("new-board", boardArguments...)
+	editor.argumentParser.AddCommand("rename-board", renameBoardArguments...)
+	editor.argumentParser.AddCommand("delete-board", boardArguments...)
+
+	editor.argumentParser.AddCommand("yesterday")
+	editor.argumentParser.AddCommand("day", dayArguments...)
+	editor.argumentParser.AddCommand("today")
+
+	editor.argumentParser.AddCommand("undo")
+	editor.argumentParser.AddCommand("redo")
+
+	editor.argumentParser.AddCommand("archive")
+	editor.argumentParser.AddCommand("auto-archive", autoArchiveArguments...)
+	editor.argumentParser.AddCommand("archived")
+	editor.argumentParser.AddCommand("restore", restoreArguments...)
+	editor.argumentParser.AddCommand("restore-backup", restoreBackupArguments...)
+
 	editor.argumentParser.Finish()
 }
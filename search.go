@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/nsf/termbox-go"
+)
+
+func (mode *EditorMode) IsSearch() bool {
+	return *mode == SearchMode
+}
+
+// opens the search prompt, the matches are highlighted while the search is typed
+func (editor *Editor) OpenSearch() {
+	editor.mode = SearchMode
+	editor.searchQuery = ""
+	editor.searchHistoryIndex = len(editor.searchHistory)
+	editor.commandInput.SetText("/")
+}
+
+func (editor *Editor) closeSearch() {
+	editor.commandInput.Reset()
+	editor.SetNormalMode()
+}
+
+func (editor *Editor) listenSearchModeEvents(event termbox.Event) {
+	if !editor.running {
+		return
+	}
+
+	if event.Type != termbox.EventKey {
+		return
+	}
+
+	if event.Key == termbox.KeyEsc {
+		editor.searchQuery = ""
+		editor.closeSearch()
+	}
+}
+
+// keeps the search (for n and N) and jumps to the first match
+func (editor *Editor) submitSearch(query string) {
+	editor.SetNormalMode()
+
+	if query == "" {
+		editor.searchQuery = ""
+		return
+	}
+
+	if last := len(editor.searchHistory) - 1; last < 0 || editor.searchHistory[last] != query {
+		editor.searchHistory = append(editor.searchHistory, query)
+	}
+
+	editor.searchQuery = query
+	editor.jumpToMatch(-1, 1)
+}
+
+// n and N, offset is 1 for the next match and -1 for the previous one
+func (editor *Editor) JumpToNextMatch(offset int) {
+	if editor.searchQuery == "" {
+		editor.SetErrorMessage("There is no search, use / to search")
+		return
+	}
+
+	start := -1
+
+	if id := editor.board.SelectedTaskId(); id != nil {
+		for index, task := range editor.board.VisibleTasks() {
+			if task.Id == *id {
+				start = index
+				break
+			}
+		}
+	}
+
+	editor.jumpToMatch(start, offset)
+}
+
+// selects the first match after the visible task at "start" (going back when offset is negative),
+// continuing from the other end of the list when needed
+func (editor *Editor) jumpToMatch(start, offset int) {
+	matches := editor.board.SearchVisibleTasks(editor.searchQuery)
+
+	if len(matches) == 0 {
+		editor.SetErrorMessage(fmt.Sprintf(`Pattern not found: "%v"`, editor.searchQuery))
+		return
+	}
+
+	visible := editor.board.VisibleTasks()
+
+	for step := 1; step <= len(visible); step++ {
+		index := ((start+step*offset)%len(visible) + len(visible)) % len(visible)
+
+		if !visible[index].NameContains(editor.searchQuery) {
+			continue
+		}
+
+		editor.setErrorMessageIfNNil(editor.board.SelectTask(visible[index].Id))
+
+		for position, match := range matches {
+			if match.Id == visible[index].Id {
+				editor.SetInfoMessage(fmt.Sprintf(`"%v" match %d of %d`, editor.searchQuery, position+1, len(matches)))
+			}
+		}
+
+		return
+	}
+}
+
+// up and down in the search prompt, offset is -1 for older searches and 1 for newer ones
+func (editor *Editor) browseSearchHistory(offset int) {
+	index := editor.searchHistoryIndex + offset
+
+	if index < 0 || index > len(editor.searchHistory) {
+		return
+	}
+
+	editor.searchHistoryIndex = index
+
+	if index == len(editor.searchHistory) {
+		editor.commandInput.SetText("/")
+	} else {
+		editor.commandInput.SetText("/" + editor.searchHistory[index])
+	}
+}
+
+// draws over the text (already drawn at x, y) the first occurrence of the search after the byte "from"
+func (editor *Editor) highlightSearch(x, y, width int, text string, from int) {
+	query := editor.searchQuery
+
+	if query == "" || from > len(text) {
+		return
+	}
+
+	index := strings.Index(strings.ToLower(text[from:]), strings.ToLower(query))
+
+	if index == -1 || from+index+len(query) > len(text) {
+		return
+	}
+
+	index += from
+
+	column := runewidth.StringWidth(text[:index])
+
+	if column >= width {
+		return
+	}
+
+	tbprintn(x+column, y, width-column, termbox.ColorBlack, termbox.ColorYellow, text[index:index+len(query)])
+}
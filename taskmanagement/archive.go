@@ -0,0 +1,256 @@
+package taskmanagement
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// a finished task (with its subtasks) that was moved out of the board
+type ArchivedTask struct {
+	Task       *Task `json:"task"`
+	ArchivedAt int64 `json:"archived_at"` // unix timestamp
+}
+
+// this is what is stored in the archive file
+type archiveData struct {
+	Entries []ArchivedTask `json:"entries"`
+}
+
+type Archive struct {
+	entries []ArchivedTask // newest first
+	synced  map[int]bool   // ids of the archived tasks as they were in the archive file when it was last loaded or saved
+}
+
+func CreateArchive() *Archive {
+	return &Archive{
+		entries: []ArchivedTask{},
+		synced:  map[int]bool{},
+	}
+}
+
+func (archive *Archive) Entries() []ArchivedTask {
+	return archive.entries
+}
+
+func (archive *Archive) IsEmpty() bool {
+	return len(archive.entries) == 0
+}
+
+// ids of the archived tasks and their subtasks
+func (archive *Archive) TaskIds() []int {
+	var ids []int
+
+	for _, entry := range archive.entries {
+		for _, task := range collectSubtree(entry.Task) {
+			ids = append(ids, task.Id)
+		}
+	}
+
+	return ids
+}
+
+func (archive *Archive) Add(tasks []*Task, now time.Time) {
+	var entries []ArchivedTask
+
+	for _, task := range tasks {
+		entries = append(entries, ArchivedTask{Task: task, ArchivedAt: now.Unix()})
+	}
+
+	archive.entries = append(entries, archive.entries...)
+}
+
+// removes the archived task with the given id and returns it
+func (archive *Archive) Take(id int) (*Task, error) {
+	for index, entry := range archive.entries {
+		if entry.Task.Id == id {
+			archive.entries = append(archive.entries[:index:index], archive.entries[index+1:]...)
+
+			return entry.Task, nil
+		}
+	}
+
+	return nil, errors.New("Archived task not found")
+}
+
+// combines the entries with the ones stored by other instances since the archive was last loaded or saved:
+// tasks archived by them are added and tasks they restored are left out, tasks archived or restored here are kept that way
+func (archive *Archive) merge(stored []ArchivedTask) []ArchivedTask {
+	inStored := map[int]bool{}
+	inArchive := map[int]bool{}
+
+	for _, entry := range stored {
+		inStored[entry.Task.Id] = true
+	}
+
+	var merged []ArchivedTask
+
+	for _, entry := range archive.entries {
+		inArchive[entry.Task.Id] = true
+
+		if inStored[entry.Task.Id] || !archive.synced[entry.Task.Id] {
+			merged = append(merged, entry)
+		}
+	}
+
+	for _, entry := range stored {
+		if !inArchive[entry.Task.Id] && !archive.synced[entry.Task.Id] {
+			merged = append(merged, entry)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].ArchivedAt > merged[j].ArchivedAt
+	})
+
+	return merged
+}
+
+// the entries now match the archive file
+func (archive *Archive) markSynced(entries []ArchivedTask) {
+	archive.entries = entries
+	archive.synced = map[int]bool{}
+
+	for _, entry := range entries {
+		archive.synced[entry.Task.Id] = true
+	}
+
+	if archive.entries == nil {
+		archive.entries = []ArchivedTask{}
+	}
+}
+
+// the task followed by all its subtasks
+func collectSubtree(task *Task) []*Task {
+	subtree := []*Task{task}
+
+	for current := task.Children; current != nil; current = current.Next {
+		subtree = append(subtree, collectSubtree(current)...)
+	}
+
+	return subtree
+}
+
+// tells if the task and all its subtasks were finished before the given unix timestamp
+func (board *Board) isArchivable(task *Task, before int64) bool {
+	if !board.IsTaskDone(task) || task.CompletedAt > before {
+		return false
+	}
+
+	for current := task.Children; current != nil; current = current.Next {
+		if !board.isArchivable(current, before) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (board *Board) takeFinishedTasks(parent *Task, before int64, taken []*Task) []*Task {
+	current := board.firstChildOf(parent)
+
+	for current != nil {
+		next := current.Next
+
+		if board.isArchivable(current, before) {
+			board.unlinkTask(current)
+
+			taken = append(taken, current)
+		} else {
+			taken = board.takeFinishedTasks(current, before, taken)
+		}
+
+		current = next
+	}
+
+	return taken
+}
+
+// the selected task may not be in the board anymore after taking tasks out of it
+func (board *Board) fixSelectionAfterTaking() {
+	if board.task != nil && board.findTaskById(board.task.Id) != board.task {
+		board.task = nil
+	}
+
+	board.ensureVisibleSelection()
+}
+
+// removes from the board the tasks finished before the given time (only when all their subtasks are
+// finished too) and returns them, each one with its subtasks
+func (board *Board) TakeFinishedTasks(before time.Time) []*Task {
+	taken := board.takeFinishedTasks(nil, before.Unix(), nil)
+
+	for _, task := range taken {
+		board.recordSubtree(task, HistoryArchived)
+	}
+
+	if len(taken) > 0 {
+		board.ForgetUndoPoints()
+	}
+
+	board.fixSelectionAfterTaking()
+
+	return taken
+}
+
+func (board *Board) AutoArchive() int {
+	return board.autoArchive
+}
+
+// finished tasks older than the given amount of days are archived automatically, 0 disables it
+func (board *Board) SetAutoArchive(days int) error {
+	if days < 0 {
+		return errors.New("The amount of days cannot be negative")
+	}
+
+	board.autoArchive = days
+
+	return nil
+}
+
+// removes from the board the tasks that must be archived by the automatic policy
+func (board *Board) TakeExpiredTasks(now time.Time) []*Task {
+	if board.autoArchive == 0 {
+		return nil
+	}
+
+	return board.TakeFinishedTasks(now.AddDate(0, 0, -board.autoArchive))
+}
+
+// keeps the given ids (like the ones of archived tasks) from being given to new tasks
+func (board *Board) ReserveTaskIds(ids []int) {
+	for _, id := range ids {
+		board.idCluster.MarkAsUsed(id)
+	}
+}
+
+// puts an archived task (with its subtasks) back on top of the board and selects it,
+// tasks whose id is in use by another task get a new one.
+// until ForgetUndoPoints is called the restore can be reverted with RevertLastChange, so it can be rolled back when it cannot be saved
+func (board *Board) RestoreTask(task *Task) {
+	board.ForgetUndoPoints()
+	board.saveUndoPoint()
+
+	for _, current := range collectSubtree(task) {
+		if board.findTaskById(current.Id) != nil {
+			current.Id = board.idCluster.NewId()
+		} else {
+			board.idCluster.MarkAsUsed(current.Id)
+		}
+	}
+
+	task.Parent = nil
+	task.Prev = nil
+	task.Next = nil
+
+	if board.priorityOrder {
+		board.insertTask(nil, task, board.priorityPositionFor(task))
+	} else {
+		board.insertTask(nil, task, board.root)
+	}
+
+	board.task = task
+	board.ensureVisibleSelection()
+
+	board.recordSubtree(task, HistoryRestored)
+}
@@ -0,0 +1,302 @@
+package taskmanagement
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestTakeFinishedTasksKeepsUnfinishedSubtrees(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("c")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	board.AddTask("b")
+	board.MoveCurrentSelectedTaskTo(completed)
+	board.AddSubtask("b.1")
+
+	board.AddTask("a")
+
+	taken := board.TakeFinishedTasks(time.Now())
+
+	if len(taken) != 1 || taken[0].Name != "c" {
+		t.Fatalf("Expected only %v to be archived, Received: %d tasks", "c", len(taken))
+	}
+
+	expectTaskNames(t, board.Tasks(), "a", "b", "b.1")
+
+	board.SelectNextTask()
+	board.SelectNextTask()
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	taken = board.TakeFinishedTasks(time.Now())
+
+	if len(taken) != 1 || taken[0].Name != "b" || len(taken[0].Subtasks()) != 1 {
+		t.Fatalf("Expected %v to be archived with its subtask", "b")
+	}
+
+	expectTaskNames(t, board.Tasks(), "a")
+
+	if board.CurrentTask() == nil || board.CurrentTask().Name != "a" {
+		t.Fatal("Expected the selection to move to a task in the board")
+	}
+}
+
+func TestTakeExpiredTasks(t *testing.T) {
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.clock = func() time.Time { return now.AddDate(0, 0, -3) }
+
+	board.AddTask("old")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	board.clock = func() time.Time { return now }
+
+	board.AddTask("new")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	if len(board.TakeExpiredTasks(now)) != 0 {
+		t.Fatal("Expected no tasks while the automatic archive is disabled")
+	}
+
+	board.SetAutoArchive(2)
+
+	taken := board.TakeExpiredTasks(now)
+
+	if len(taken) != 1 || taken[0].Name != "old" {
+		t.Fatalf("Expected only %v to be archived", "old")
+	}
+}
+
+func TestRestoreTaskWithIdInUse(t *testing.T) {
+	board := CreateBoard()
+
+	task := board.AddTask("a")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	taken := board.TakeFinishedTasks(time.Now())
+
+	b := board.AddTask("b")
+	restored := &Task{Id: b.Id, Name: "archived"}
+
+	board.RestoreTask(taken[0])
+	board.RestoreTask(restored)
+
+	if board.CurrentTask() != restored {
+		t.Fatal("Expected the restored task to be selected")
+	}
+
+	if restored.Id == b.Id {
+		t.Fatalf("Expected the restored task to get a new id instead of %d", b.Id)
+	}
+
+	ids := map[int]bool{}
+
+	for _, current := range board.Tasks() {
+		if ids[current.Id] {
+			t.Fatalf("Id %d is used more than once", current.Id)
+		}
+
+		ids[current.Id] = true
+	}
+
+	if !ids[task.Id] {
+		t.Fatalf("Expected the restored task to keep the id %d", task.Id)
+	}
+}
+
+func TestSaveAndLoadArchive(t *testing.T) {
+	repository := createTestRepository(t)
+	repository.archivePath = path.Join(t.TempDir(), archiveName)
+
+	archive, err := repository.LoadArchive()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	board := CreateBoard()
+	board.AddTask("a")
+	board.AddSubtask("a.1")
+	board.MoveCurrentSelectedTaskTo(completed)
+	board.SelectParentTask()
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	archive.Add(board.TakeFinishedTasks(time.Now()), time.Now())
+
+	if err := repository.SaveArchive(archive); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := repository.LoadArchive()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := loaded.Entries()
+
+	if len(entries) != 1 || entries[0].Task.Name != "a" || len(entries[0].Task.Subtasks()) != 1 {
+		t.Fatalf("Expected the archived task with its subtask, Received: %+v", entries)
+	}
+
+	if len(loaded.TaskIds()) != 2 {
+		t.Fatalf("Expected: %d, Received: %d", 2, len(loaded.TaskIds()))
+	}
+}
+
+func TestRevertRestoredTask(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	taken := board.TakeFinishedTasks(time.Now())
+
+	board.AddTask("b")
+	history := len(board.history)
+
+	board.RestoreTask(taken[0])
+
+	if err := board.RevertLastChange(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectTaskNames(t, board.Tasks(), "b")
+
+	if len(board.history) != history {
+		t.Fatalf("Expected: %d, Received: %d", history, len(board.history))
+	}
+
+	if board.CanUndo() {
+		t.Fatalf("Expected: %v, Received: %v", false, true)
+	}
+}
+
+func archivedTask(id int, name string) []*Task {
+	return []*Task{{Id: id, Name: name, State: completed}}
+}
+
+func expectArchivedNames(t *testing.T, archive *Archive, expected ...string) {
+	t.Helper()
+
+	var tasks []Task
+
+	for _, entry := range archive.Entries() {
+		tasks = append(tasks, *entry.Task)
+	}
+
+	expectTaskNames(t, tasks, expected...)
+}
+
+func TestSaveArchiveKeepsTasksArchivedByAnotherInstance(t *testing.T) {
+	repository := createTestRepository(t)
+
+	first, _ := repository.LoadArchive()
+	second, _ := repository.LoadArchive()
+
+	first.Add(archivedTask(1, "a"), time.Unix(100, 0))
+
+	if err := repository.SaveArchive(first); err != nil {
+		t.Fatal(err)
+	}
+
+	second.Add(archivedTask(2, "b"), time.Unix(200, 0))
+
+	if err := repository.SaveArchive(second); err != nil {
+		t.Fatal(err)
+	}
+
+	expectArchivedNames(t, second, "b", "a")
+
+	loaded, err := repository.LoadArchive()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectArchivedNames(t, loaded, "b", "a")
+}
+
+func TestSaveArchiveKeepsTasksRestoredByAnotherInstance(t *testing.T) {
+	repository := createTestRepository(t)
+
+	first, _ := repository.LoadArchive()
+	first.Add(archivedTask(1, "a"), time.Unix(100, 0))
+	first.Add(archivedTask(2, "b"), time.Unix(200, 0))
+
+	if err := repository.SaveArchive(first); err != nil {
+		t.Fatal(err)
+	}
+
+	second, _ := repository.LoadArchive()
+
+	// restored in the first instance
+	first.Take(first.Entries()[0].Task.Id)
+
+	if err := repository.SaveArchive(first); err != nil {
+		t.Fatal(err)
+	}
+
+	second.Add(archivedTask(3, "c"), time.Unix(300, 0))
+
+	if err := repository.SaveArchive(second); err != nil {
+		t.Fatal(err)
+	}
+
+	expectArchivedNames(t, second, "c", "a")
+}
+
+func TestSaveArchiveRecreatesDeletedFile(t *testing.T) {
+	repository := createTestRepository(t)
+
+	archive, _ := repository.LoadArchive()
+	archive.Add(archivedTask(1, "a"), time.Unix(100, 0))
+
+	if err := repository.SaveArchive(archive); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(repository.archivePath); err != nil {
+		t.Fatal(err)
+	}
+
+	if status := repository.CheckArchive(); status != DatabaseDeleted {
+		t.Fatalf("Expected: %v, Received: %v", DatabaseDeleted, status)
+	}
+
+	if err := repository.SaveArchive(archive); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := repository.LoadArchive()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectArchivedNames(t, loaded, "a")
+}
+
+func TestCheckArchiveReportsChangesByAnotherInstance(t *testing.T) {
+	repository := createTestRepository(t)
+	other := &Repository{path: repository.path, archivePath: repository.archivePath}
+
+	archive, _ := other.LoadArchive()
+	archive.Add(archivedTask(1, "a"), time.Unix(100, 0))
+
+	if err := other.SaveArchive(archive); err != nil {
+		t.Fatal(err)
+	}
+
+	if status := repository.CheckArchive(); status != DatabaseModified {
+		t.Fatalf("Expected: %v, Received: %v", DatabaseModified, status)
+	}
+
+	if status := other.CheckArchive(); status != DatabaseUnchanged {
+		t.Fatalf("Expected: %v, Received: %v", DatabaseUnchanged, status)
+	}
+}
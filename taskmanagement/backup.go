@@ -0,0 +1,113 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// how many previous versions of the database are kept
+const MaxBackups = 5
+
+// a new backup is kept only when the newest one is older than this,
+// so the backups go back further than the last few saves
+const BackupInterval = 10 * time.Minute
+
+type Backup struct {
+	Number  int
+	SavedAt time.Time
+}
+
+// backups are numbered from the newest (1) to the oldest (MaxBackups)
+func (r *Repository) backupPath(number int) string {
+	return fmt.Sprintf("%v.%d", r.path, number)
+}
+
+// shifts every backup one position and keeps the current database as the newest one.
+// unless forced, nothing is done while the newest backup is more recent than BackupInterval
+func (r *Repository) rotateBackups(force bool) error {
+	if stat, err := os.Stat(r.backupPath(1)); !force && err == nil && time.Since(stat.ModTime()) < BackupInterval {
+		return nil
+	}
+
+	bytes, err := os.ReadFile(r.path)
+
+	if os.IsNotExist(err) || (err == nil && len(bytes) == 0) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for number := MaxBackups - 1; number >= 1; number-- {
+		err = os.Rename(r.backupPath(number), r.backupPath(number+1))
+
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return writeFileAtomically(r.backupPath(1), bytes)
+}
+
+// the backups that currently exist, the newest first
+func (r *Repository) Backups() []Backup {
+	backups := make([]Backup, 0, MaxBackups)
+
+	for number := 1; number <= MaxBackups; number++ {
+		stat, err := os.Stat(r.backupPath(number))
+
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, Backup{Number: number, SavedAt: stat.ModTime()})
+	}
+
+	return backups
+}
+
+// replaces the database with the given backup, the replaced database becomes the newest backup
+func (r *Repository) RestoreBackup(number int) error {
+	if number < 1 || number > MaxBackups {
+		return errors.New(fmt.Sprintf("Backups go from 1 to %d", MaxBackups))
+	}
+
+	bytes, err := os.ReadFile(r.backupPath(number))
+
+	if os.IsNotExist(err) {
+		return errors.New(fmt.Sprintf("Backup %d does not exist", number))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	backup, err := decodeDatabase(bytes)
+
+	if err != nil {
+		return errors.New(fmt.Sprintf("Backup %d is invalid: %v", number, err.Error()))
+	}
+
+	backup.normalize()
+
+	return r.updateDatabase(func(database *databaseData) error {
+		// the replaced database is always kept, so the restore can be undone
+		if err := r.rotateBackups(true); err != nil {
+			return errors.New(fmt.Sprintf("Could not keep a backup of the database: %v", err.Error()))
+		}
+
+		// restored boards get a new revision, so instances that loaded the replaced boards cannot overwrite them
+		for index, board := range backup.Boards {
+			if current := database.find(board.Name); current != -1 {
+				backup.Boards[index].Revision = database.Boards[current].Revision + 1
+			}
+		}
+
+		*database = *backup
+
+		return nil
+	})
+}
@@ -0,0 +1,151 @@
+package taskmanagement
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// replaces the stored tasks of the default board
+func saveTaskNames(t *testing.T, repository *Repository, names ...string) {
+	stored := CreateBoard()
+	repository.LoadBoard(stored)
+
+	board := CreateBoard()
+	board.revision = stored.revision
+
+	for _, name := range names {
+		board.AddTask(name)
+	}
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// makes the existing backups older than BackupInterval, so the next save keeps a new one
+func ageBackups(t *testing.T, repository *Repository) {
+	past := time.Now().Add(-BackupInterval)
+
+	for _, backup := range repository.Backups() {
+		if err := os.Chtimes(repository.backupPath(backup.Number), past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSaveBoardLeavesNoTemporaryFiles(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+	saveTaskNames(t, repository, "a", "b")
+
+	files, err := os.ReadDir(filepath.Dir(repository.path))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+
+	expected := []string{databaseName, databaseName + ".1", databaseName + ".lock"}
+
+	if len(names) != len(expected) || names[0] != expected[0] || names[1] != expected[1] || names[2] != expected[2] {
+		t.Fatalf("Expected: %v, Received: %v", expected, names)
+	}
+}
+
+func TestSaveBoardRotatesBackups(t *testing.T) {
+	repository := createTestRepository(t)
+
+	for i := 0; i < MaxBackups+3; i++ {
+		ageBackups(t, repository)
+		saveTaskNames(t, repository, "a")
+	}
+
+	backups := repository.Backups()
+
+	if len(backups) != MaxBackups {
+		t.Fatalf("Expected: %d, Received: %d", MaxBackups, len(backups))
+	}
+
+	for index, backup := range backups {
+		if backup.Number != index+1 {
+			t.Fatalf("Expected: %d, Received: %d", index+1, backup.Number)
+		}
+	}
+}
+
+func TestSaveBoardKeepsOneBackupPerInterval(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+	saveTaskNames(t, repository, "a", "b")
+	saveTaskNames(t, repository, "a", "b", "c")
+
+	if backups := repository.Backups(); len(backups) != 1 {
+		t.Fatalf("Expected: %d, Received: %d", 1, len(backups))
+	}
+
+	ageBackups(t, repository)
+	saveTaskNames(t, repository, "a", "b", "c", "d")
+
+	if backups := repository.Backups(); len(backups) != 2 {
+		t.Fatalf("Expected: %d, Received: %d", 2, len(backups))
+	}
+
+	// the older backup is the first save and the newer one the save right before the last
+	if err := repository.RestoreBackup(2); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := CreateBoard()
+	repository.LoadBoard(restored)
+	expectTaskNames(t, restored.Tasks(), "a")
+}
+
+func TestRestoreBackup(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+	ageBackups(t, repository)
+	saveTaskNames(t, repository, "a", "b")
+	ageBackups(t, repository)
+	saveTaskNames(t, repository, "a", "b", "c")
+
+	if err := repository.RestoreBackup(2); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := CreateBoard()
+	repository.LoadBoard(restored)
+	expectTaskNames(t, restored.Tasks(), "a")
+
+	// the replaced version is kept, so the restore can be undone
+	if err := repository.RestoreBackup(1); err != nil {
+		t.Fatal(err)
+	}
+
+	restored = CreateBoard()
+	repository.LoadBoard(restored)
+	expectTaskNames(t, restored.Tasks(), "c", "b", "a")
+}
+
+func TestRestoreMissingBackup(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+
+	if err := repository.RestoreBackup(1); err == nil {
+		t.Fatalf("Expected: %v, Received: %v", "error", err)
+	}
+
+	if err := repository.RestoreBackup(MaxBackups + 1); err == nil {
+		t.Fatalf("Expected: %v, Received: %v", "error", err)
+	}
+}
@@ -2,86 +2,190 @@ package taskmanagement
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/marcos-venicius/daily-term/idcluster"
 )
 
 func CreateBoard() *Board {
+	return CreateNamedBoard(DefaultBoardName)
+}
+
+func CreateNamedBoard(name string) *Board {
 	idCluster := idcluster.CreateIdCluster()
 
 	return &Board{
+		name:      name,
 		task:      nil,
 		root:      nil,
 		idCluster: idCluster,
+		clock:     time.Now,
+		workflow:  DefaultWorkflow(),
 	}
 }
 
-func (board *Board) SetCustomTaskState(state TaskState) error {
-	if board.task == nil {
-		return errors.New("You have no selected task")
-	}
+func (board *Board) Workflow() *Workflow {
+	return board.workflow
+}
 
-	if board.task.State == state {
-		switch state {
-		case Todo:
-			return errors.New("This task is already todo")
-		case InProgress:
-			return errors.New("This task is already in progress")
-		case Completed:
-			return errors.New("This task is already completed")
-		default:
-			return errors.New("This task is already in unknown state")
-		}
+func (board *Board) SetWorkflow(workflow *Workflow) {
+	board.workflow = workflow
+}
+
+func (board *Board) IsTaskDone(task *Task) bool {
+	return board.workflow.IsDone(task.State)
+}
+
+// parses a due date typed by the user, besides the DueDateLayout format it also accepts
+// "today", "tomorrow" and "none" (which removes the due date)
+func parseDueDate(value string, now time.Time) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "none", "":
+		return "", nil
+	case "today":
+		return now.Format(DueDateLayout), nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1).Format(DueDateLayout), nil
 	}
 
-	board.task.State = state
+	date, err := time.Parse(DueDateLayout, value)
 
-	return nil
+	if err != nil {
+		return "", errors.New(fmt.Sprintf(`Invalid date "%v", expected format is YYYY-MM-DD`, value))
+	}
+
+	return date.Format(DueDateLayout), nil
 }
 
-func (board *Board) CurrentTask() *Task {
-	return board.task
+// sets the due date of the task with the given id and returns the previous one
+func (board *Board) SetTaskDueDate(id int, date string) (string, error) {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return "", errors.New("Task not found")
+	}
+
+	dueDate, err := parseDueDate(date, time.Now())
+
+	if err != nil {
+		return "", err
+	}
+
+	board.saveUndoPoint()
+
+	previousDueDate := task.DueDate
+	task.DueDate = dueDate
+
+	return previousDueDate, nil
 }
 
-func (board *Board) MoveCurrentSelectedTaskToTodo() error {
-	if board.task == nil {
-		return errors.New("You have no selected task")
+// renames the task with the given id and returns the previous name
+func (board *Board) RenameTask(id int, name string) (string, error) {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return "", errors.New("Task not found")
 	}
 
-	if board.task.State == Todo {
-		return errors.New("This task is already todo")
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", errors.New("Task name cannot be empty")
+	}
+
+	if name == task.Name {
+		return "", errors.New("This task already has this name")
 	}
 
-	board.task.State = Todo
+	board.saveUndoPoint()
 
-	return nil
+	previousName := task.Name
+	task.Name = name
+
+	board.record(task, HistoryRenamed, previousName, name)
+	board.ensureVisibleSelection()
+
+	return previousName, nil
 }
 
-func (board *Board) MoveCurrentSelectedTaskToInProgress() error {
-	if board.task == nil {
-		return errors.New("You have no selected task")
+// sets the notes of the task with the given id and returns the previous ones
+func (board *Board) SetTaskNotes(id int, notes string) (string, error) {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return "", errors.New("Task not found")
+	}
+
+	board.saveUndoPoint()
+
+	previousNotes := task.Notes
+	task.Notes = notes
+
+	board.ensureVisibleSelection()
+
+	return previousNotes, nil
+}
+
+// appends a new line to the notes of the task with the given id and returns the previous notes
+func (board *Board) AppendTaskNote(id int, line string) (string, error) {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return "", errors.New("Task not found")
 	}
 
-	if board.task.State == InProgress {
-		return errors.New("This task is already in progress mode")
+	if strings.TrimSpace(line) == "" {
+		return "", errors.New("Note cannot be empty")
 	}
 
-	board.task.State = InProgress
+	notes := line
 
-	return nil
+	if task.Notes != "" {
+		notes = task.Notes + "\n" + line
+	}
+
+	return board.SetTaskNotes(id, notes)
+}
+
+func (board *Board) CurrentTask() *Task {
+	return board.task
 }
 
-func (board *Board) MoveCurrentSelectedTaskToCompleted() error {
+// moves the selected task to the given state when the workflow allows it and returns the moved task.
+// when the filters hide the moved task, another task (or none) is selected, so the selection must not be used to find the moved task
+func (board *Board) MoveCurrentSelectedTaskTo(state TaskState) (Task, error) {
 	if board.task == nil {
-		return errors.New("You have no selected task")
+		return Task{}, errors.New("You have no selected task")
+	}
+
+	target, ok := board.workflow.State(state)
+
+	if !ok {
+		return Task{}, errors.New("Unknown state")
 	}
 
-	if board.task.State == Completed {
-		return errors.New("This task is already completed")
+	if board.task.State == state {
+		return Task{}, errors.New(fmt.Sprintf("This task is already %v", strings.ToLower(target.Name)))
 	}
 
-	board.task.State = Completed
+	if !board.workflow.CanMove(board.task.State, state) {
+		return Task{}, errors.New(fmt.Sprintf(`A task cannot move from "%v" to "%v"`, board.workflow.Name(board.task.State), target.Name))
+	}
 
-	return nil
+	if blockers := board.OpenBlockers(board.task); target.Done && len(blockers) > 0 {
+		return Task{}, blockedError(blockers)
+	}
+
+	moved := board.task
+
+	board.saveUndoPoint()
+	board.setTaskState(moved, state)
+	board.ensureVisibleSelection()
+
+	return *moved, nil
 }
 
 func (board *Board) HasTasks() bool {
@@ -96,44 +200,209 @@ func (board *Board) SelectedTaskId() *int {
 	return nil
 }
 
-func (board *Board) SelectNextTask() {
-	if board.task != nil && board.task.Next != nil {
-		board.task = board.task.Next
+// tells if the task passes the current board filters and is not inside a folded task
+func (board *Board) isVisible(task *Task) bool {
+	if !board.passesFilters(task) {
+		return false
 	}
+
+	for parent := task.Parent; parent != nil; parent = parent.Parent {
+		if parent.Folded {
+			return false
+		}
+	}
+
+	return true
+}
+
+// when the selected task is hidden, selects its closest visible parent or the first visible task
+func (board *Board) ensureVisibleSelection() {
+	if board.task != nil && board.isVisible(board.task) {
+		return
+	}
+
+	if board.task != nil {
+		for parent := board.task.Parent; parent != nil; parent = parent.Parent {
+			if board.isVisible(parent) {
+				board.task = parent
+				return
+			}
+		}
+	}
+
+	board.task = nil
+
+	if visible := board.collectTasks(board.root, true, nil); len(visible) > 0 {
+		board.task = visible[0]
+	}
+}
+
+// moves the selection by "offset" positions through the visible tasks
+func (board *Board) moveSelection(offset int) {
+	if board.task == nil {
+		return
+	}
+
+	visible := board.collectTasks(board.root, true, nil)
+
+	for index, task := range visible {
+		if task == board.task {
+			if index+offset >= 0 && index+offset < len(visible) {
+				board.task = visible[index+offset]
+			}
+
+			return
+		}
+	}
+}
+
+// selects the task with the given id when it is visible
+func (board *Board) SelectTask(id int) error {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return errors.New("Task not found")
+	}
+
+	if !board.isVisible(task) {
+		return errors.New("This task is hidden by the filters or by a folded task")
+	}
+
+	board.task = task
+
+	return nil
+}
+
+// visible tasks whose name has the given text (ignoring case), in the order they are shown
+func (board *Board) SearchVisibleTasks(text string) []Task {
+	var matches []Task
+
+	if text == "" {
+		return matches
+	}
+
+	for _, task := range board.collectTasks(board.root, true, nil) {
+		if task.NameContains(text) {
+			matches = append(matches, *task)
+		}
+	}
+
+	return matches
+}
+
+func (board *Board) SelectNextTask() {
+	board.moveSelection(1)
 }
 
 func (board *Board) SelectPreviousTask() {
-	if board.task != nil && board.task.Prev != nil {
-		board.task = board.task.Prev
+	board.moveSelection(-1)
+}
+
+func (board *Board) AddTaskTag(id int, tag string) error {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return errors.New("Task not found")
+	}
+
+	tag, err := normalizeTag(tag)
+
+	if err != nil {
+		return err
+	}
+
+	if task.HasTag(tag) {
+		return errors.New(fmt.Sprintf(`This task already has the tag "%v"`, tag))
 	}
+
+	board.saveUndoPoint()
+
+	task.Tags = append(task.Tags, tag)
+
+	return nil
+}
+
+func (board *Board) RemoveTaskTag(id int, tag string) error {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return errors.New("Task not found")
+	}
+
+	tag, err := normalizeTag(tag)
+
+	if err != nil {
+		return err
+	}
+
+	for index, t := range task.Tags {
+		if t == tag {
+			board.saveUndoPoint()
+
+			task.Tags = append(task.Tags[:index:index], task.Tags[index+1:]...)
+
+			board.ensureVisibleSelection()
+
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf(`This task does not have the tag "%v"`, tag))
+}
+
+func (board *Board) TagFilter() string {
+	return board.tagFilter
+}
+
+// restricts the visible tasks to the ones with the given tag, an empty tag removes the filter
+func (board *Board) SetTagFilter(tag string) error {
+	if tag == "" {
+		board.tagFilter = ""
+	} else {
+		tag, err := normalizeTag(tag)
+
+		if err != nil {
+			return err
+		}
+
+		board.tagFilter = tag
+	}
+
+	board.ensureVisibleSelection()
+
+	return nil
+}
+
+// removes the task (and its subtasks) from the board, when it is the selected one
+// the selection goes to its neighbour or to its parent
+func (board *Board) removeTask(task *Task) {
+	if board.task == task {
+		board.task = task.Next
+
+		if board.task == nil {
+			board.task = task.Prev
+		}
+
+		if board.task == nil {
+			board.task = task.Parent
+		}
+	}
+
+	board.recordSubtree(task, HistoryDeleted)
+
+	board.unlinkTask(task)
+	board.ensureVisibleSelection()
 }
 
 func (board *Board) DeleteCurrentSelectedTask() error {
-	if board.root == nil {
+	if board.root == nil || board.task == nil {
 		return errors.New("You don't have any selected task")
 	}
 
-	if board.task.Next == nil && board.task.Prev == nil {
-		board.root = nil
-		board.task = nil
-		return nil
-	} else if board.task.Prev != nil && board.task.Next != nil {
-		board.task.Next.Prev, board.task.Prev.Next = board.task.Prev, board.task.Next
-		board.task = board.task.Next
-		return nil
-	} else if board.task.Prev == nil && board.task.Next != nil {
-		board.task = board.task.Next
-		board.task.Prev = nil
-		board.root = board.task
-		return nil
-	} else if board.task.Next == nil && board.task.Prev != nil {
-		board.task = board.task.Prev
-		board.task.Next = nil
-		board.root = board.task
-		return nil
-	}
+	board.saveUndoPoint()
+	board.removeTask(board.task)
 
-	return errors.New("Something went wrong")
+	return nil
 }
 
 func (board *Board) DeleteTaskById(id int) error {
@@ -141,85 +410,173 @@ func (board *Board) DeleteTaskById(id int) error {
 		return errors.New("You don't have any selected task")
 	}
 
-	current := board.root
-
-	for current != nil {
-		if current.Id == id {
-			if current.Next == nil && current.Prev == nil {
-				board.root = nil
-				current = nil
-				return nil
-			} else if current.Prev != nil && current.Next != nil {
-				current.Next.Prev, current.Prev.Next = current.Prev, current.Next
-				current = current.Next
-				board.task = current
-				return nil
-			} else if current.Prev == nil && current.Next != nil {
-				current = current.Next
-				current.Prev = nil
-				board.root = current
-				board.task = current
-				return nil
-			} else if current.Next == nil && current.Prev != nil {
-				current = current.Prev
-				current.Next = nil
-				board.root = current
-				board.task = current
-				return nil
-			}
+	task := board.findTaskById(id)
 
-			return errors.New("Something went wrong")
-		}
+	if task == nil {
+		return errors.New("Task not found")
+	}
+
+	board.saveUndoPoint()
+	board.removeTask(task)
 
-		current = current.Next
+	return nil
+}
+
+// first sibling that should come after the given task when the board is ordered by priority
+func (board *Board) priorityPositionFor(task *Task) *Task {
+	at := board.firstChildOf(task.Parent)
+
+	for at != nil && (at == task || at.Priority > task.Priority) {
+		at = at.Next
 	}
 
-	return errors.New("Task not found")
+	return at
 }
 
-func (board *Board) AddTask(name string) Task {
-	task := Task{
-		Id:    board.idCluster.NewId(),
-		Name:  name,
-		State: Todo,
-		Prev:  nil,
-		Next:  nil,
+func (board *Board) placeTaskByPriority(task *Task) {
+	parent := task.Parent
+	at := board.priorityPositionFor(task)
+
+	board.unlinkTask(task)
+	board.insertTask(parent, task, at)
+}
+
+// sorts the children of parent (and all their subtasks) by priority
+func (board *Board) sortByPriority(parent *Task) {
+	var tasks []*Task
+
+	for current := board.firstChildOf(parent); current != nil; current = current.Next {
+		tasks = append(tasks, current)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Priority > tasks[j].Priority
+	})
+
+	board.setFirstChildOf(parent, nil)
+
+	for _, task := range tasks {
+		board.appendTask(parent, task)
+		board.sortByPriority(task)
 	}
+}
+
+func (board *Board) PriorityOrder() bool {
+	return board.priorityOrder
+}
+
+// when enabled, the board is sorted by priority and keeps higher priority tasks on top
+func (board *Board) SetPriorityOrder(enabled bool) {
+	board.saveUndoPoint()
+
+	board.priorityOrder = enabled
+
+	if enabled {
+		board.sortByPriority(nil)
+	}
+}
+
+// sets the priority of the task with the given id and returns the previous one
+func (board *Board) SetTaskPriority(id int, priority TaskPriority) (TaskPriority, error) {
+	task := board.findTaskById(id)
 
+	if task == nil {
+		return PriorityNone, errors.New("Task not found")
+	}
+
+	if priority < PriorityNone || priority > PriorityUrgent {
+		return PriorityNone, errors.New("Invalid priority")
+	}
+
+	board.saveUndoPoint()
+
+	previousPriority := task.Priority
+	task.Priority = priority
+
+	if board.priorityOrder {
+		board.placeTaskByPriority(task)
+	}
+
+	return previousPriority, nil
+}
+
+func (board *Board) IncreaseCurrentTaskPriority() error {
 	if board.task == nil {
-		board.task = &task
-		board.root = board.task
-	} else {
-		task.Next = board.root
-		board.root.Prev = &task
-		board.root = &task
-		board.task = board.root
+		return errors.New("You have no selected task")
 	}
 
-	if board.root.Prev != nil {
-		panic("Invalid root")
+	if board.task.Priority >= PriorityUrgent {
+		return errors.New("This task already has the highest priority")
 	}
 
-	return task
+	_, err := board.SetTaskPriority(board.task.Id, board.task.Priority+1)
+
+	return err
 }
 
-func (board *Board) Tasks() []Task {
+func (board *Board) DecreaseCurrentTaskPriority() error {
 	if board.task == nil {
-		return []Task{}
+		return errors.New("You have no selected task")
 	}
 
-	var tasks []Task
+	if board.task.Priority <= PriorityNone {
+		return errors.New("This task already has no priority")
+	}
+
+	_, err := board.SetTaskPriority(board.task.Id, board.task.Priority-1)
+
+	return err
+}
+
+func (board *Board) AddTask(name string) Task {
+	board.saveUndoPoint()
+
+	task := &Task{
+		Id:        board.idCluster.NewId(),
+		Name:      name,
+		State:     board.workflow.Initial(),
+		Priority:  PriorityNone,
+		CreatedAt: board.clock().Unix(),
+		Prev:      nil,
+		Next:      nil,
+	}
+
+	if board.priorityOrder {
+		board.insertTask(nil, task, board.priorityPositionFor(task))
+	} else {
+		board.insertTask(nil, task, board.root)
+	}
 
-	current := board.root
+	board.task = task
 
-	for current.Prev != nil {
-		current = current.Prev
+	board.record(task, HistoryCreated, "", task.Name)
+
+	if board.root.Prev != nil {
+		panic("Invalid root")
+	}
+
+	board.ensureVisibleSelection()
+
+	return *task
+}
+
+// all tasks of the board, each task is followed by its subtasks
+func (board *Board) Tasks() []Task {
+	tasks := []Task{}
+
+	for _, task := range board.collectTasks(board.root, false, nil) {
+		tasks = append(tasks, *task)
 	}
 
-	for current != nil {
-		tasks = append(tasks, *current)
+	return tasks
+}
+
+// tasks that pass the current board filters and are not inside a folded task, in board order
+func (board *Board) VisibleTasks() []Task {
+	var tasks []Task
 
-		current = current.Next
+	for _, task := range board.collectTasks(board.root, true, nil) {
+		tasks = append(tasks, *task)
 	}
 
 	return tasks
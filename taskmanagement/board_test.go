@@ -0,0 +1,228 @@
+package taskmanagement
+
+import (
+	"testing"
+)
+
+// states of the default workflow
+const (
+	todo       TaskState = 0
+	inProgress TaskState = 1
+	completed  TaskState = 2
+)
+
+// use board.Tasks() or board.VisibleTasks() as tasks
+func expectTaskNames(t *testing.T, tasks []Task, expected ...string) {
+	t.Helper()
+
+	var names []string
+
+	for _, task := range tasks {
+		names = append(names, task.Name)
+	}
+
+	if len(names) != len(expected) {
+		t.Fatalf("Expected: %v, Received: %v", expected, names)
+	}
+
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Fatalf("Expected: %v, Received: %v", expected, names)
+		}
+	}
+}
+
+func TestAddTaskPrependsTasks(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.AddTask("b")
+	board.AddTask("c")
+
+	expectTaskNames(t, board.Tasks(), "c", "b", "a")
+}
+
+func TestDeleteLastTaskKeepsRoot(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.AddTask("b")
+
+	board.SelectNextTask()
+
+	if err := board.DeleteCurrentSelectedTask(); err != nil {
+		t.Fatal(err)
+	}
+
+	board.AddTask("c")
+
+	expectTaskNames(t, board.Tasks(), "c", "b")
+}
+
+func TestPriorityOrderKeepsHigherPriorityTasksOnTop(t *testing.T) {
+	board := CreateBoard()
+
+	a := board.AddTask("a")
+	b := board.AddTask("b")
+	board.AddTask("c")
+
+	board.SetTaskPriority(a.Id, PriorityHigh)
+	board.SetTaskPriority(b.Id, PriorityLow)
+
+	expectTaskNames(t, board.Tasks(), "c", "b", "a")
+
+	board.SetPriorityOrder(true)
+
+	expectTaskNames(t, board.Tasks(), "a", "b", "c")
+
+	board.AddTask("d")
+
+	expectTaskNames(t, board.Tasks(), "a", "b", "d", "c")
+
+	board.SetTaskPriority(b.Id, PriorityUrgent)
+
+	expectTaskNames(t, board.Tasks(), "b", "a", "d", "c")
+}
+
+func TestChangeCurrentTaskPriorityBounds(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+
+	if err := board.DecreaseCurrentTaskPriority(); err == nil {
+		t.Fatal("Error expected but received nil")
+	}
+
+	for i := 0; i < int(PriorityUrgent); i++ {
+		if err := board.IncreaseCurrentTaskPriority(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := board.IncreaseCurrentTaskPriority(); err == nil {
+		t.Fatal("Error expected but received nil")
+	}
+}
+
+func TestTaskTags(t *testing.T) {
+	board := CreateBoard()
+
+	task := board.AddTask("a")
+
+	if err := board.AddTaskTag(task.Id, "#Work"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := board.AddTaskTag(task.Id, "work"); err == nil {
+		t.Fatal("Error expected but received nil")
+	}
+
+	if !board.CurrentTask().HasTag("work") {
+		t.Fatalf("Expected: %v, Received: %v", []string{"work"}, board.CurrentTask().Tags)
+	}
+
+	if err := board.RemoveTaskTag(task.Id, "work"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(board.CurrentTask().Tags) != 0 {
+		t.Fatalf("Expected: %d, Received: %d", 0, len(board.CurrentTask().Tags))
+	}
+}
+
+func TestTagFilterRestrictsVisibleTasksAndNavigation(t *testing.T) {
+	board := CreateBoard()
+
+	a := board.AddTask("a")
+	board.AddTask("b")
+	c := board.AddTask("c")
+
+	board.AddTaskTag(a.Id, "work")
+	board.AddTaskTag(c.Id, "work")
+
+	board.SetTagFilter("work")
+
+	expectTaskNames(t, board.VisibleTasks(), "c", "a")
+
+	board.SelectNextTask()
+
+	if board.CurrentTask().Id != a.Id {
+		t.Fatalf("Expected: %v, Received: %v", a.Name, board.CurrentTask().Name)
+	}
+
+	board.SetTagFilter("")
+
+	if len(board.VisibleTasks()) != 3 {
+		t.Fatalf("Expected: %d, Received: %d", 3, len(board.VisibleTasks()))
+	}
+}
+
+func TestAppendTaskNote(t *testing.T) {
+	board := CreateBoard()
+
+	task := board.AddTask("a")
+
+	board.AppendTaskNote(task.Id, "ticket DT-42")
+	board.AppendTaskNote(task.Id, "run make deploy")
+
+	expected := "ticket DT-42\nrun make deploy"
+
+	if board.CurrentTask().Notes != expected {
+		t.Fatalf("Expected: %q, Received: %q", expected, board.CurrentTask().Notes)
+	}
+
+	if _, err := board.AppendTaskNote(task.Id, "  "); err == nil {
+		t.Fatal("Error expected but received nil")
+	}
+}
+
+func TestRenameTaskKeepsId(t *testing.T) {
+	board := CreateBoard()
+
+	task := board.AddTask("fix tpyo")
+
+	previous, err := board.RenameTask(task.Id, "  fix typo ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if previous != "fix tpyo" {
+		t.Fatalf("Expected: %v, Received: %v", "fix tpyo", previous)
+	}
+
+	if current := board.CurrentTask(); current.Id != task.Id || current.Name != "fix typo" {
+		t.Fatalf("Expected: %v, Received: %v", "fix typo", current.Name)
+	}
+
+	if _, err := board.RenameTask(task.Id, " "); err == nil {
+		t.Fatal("Expected an error for an empty name")
+	}
+
+	entries := board.TaskHistory(task.Id)
+
+	if last := entries[len(entries)-1]; last.Describe() != `renamed "fix tpyo" to "fix typo"` {
+		t.Fatalf("Expected: %v, Received: %v", `renamed "fix tpyo" to "fix typo"`, last.Describe())
+	}
+}
+
+func TestAddTaskIgnoresTagFilter(t *testing.T) {
+	board := CreateBoard()
+
+	task := board.AddTask("a")
+
+	board.AddTaskTag(task.Id, "work")
+	board.SetTagFilter("work")
+
+	subtask, err := board.AddSubtask("a.1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task = board.AddTask("b")
+
+	if len(task.Tags) != 0 || len(subtask.Tags) != 0 {
+		t.Fatalf("Expected: %v, Received: %v %v", []string{}, task.Tags, subtask.Tags)
+	}
+}
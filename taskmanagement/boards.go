@@ -0,0 +1,135 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+)
+
+// name of the board used when the database has no boards yet
+const DefaultBoardName = "main"
+
+func (board *Board) Name() string {
+	return board.name
+}
+
+// only changes the name in memory, stored boards are renamed with Repository.RenameBoard
+func (board *Board) SetName(name string) {
+	board.name = name
+}
+
+// board names can have letters, digits, "-" and "_"
+func ValidateBoardName(name string) error {
+	if name == "" {
+		return errors.New("Board name cannot be empty")
+	}
+
+	for _, ch := range name {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_') {
+			return errors.New(fmt.Sprintf(`Invalid board name "%v", use only letters, digits, "-" and "_"`, name))
+		}
+	}
+
+	return nil
+}
+
+// names of all stored boards, in creation order
+func (r *Repository) BoardNames() ([]string, error) {
+	database, err := r.readDatabase()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, board := range database.Boards {
+		names = append(names, board.Name)
+	}
+
+	return names, nil
+}
+
+func (r *Repository) ActiveBoardName() (string, error) {
+	database, err := r.readDatabase()
+
+	if err != nil {
+		return DefaultBoardName, err
+	}
+
+	return database.ActiveBoard, nil
+}
+
+// the active board is the one opened when the application starts
+func (r *Repository) SetActiveBoard(name string) error {
+	return r.updateDatabase(func(database *databaseData) error {
+		if database.find(name) == -1 {
+			return errors.New(fmt.Sprintf(`Board "%v" not found`, name))
+		}
+
+		database.ActiveBoard = name
+
+		return nil
+	})
+}
+
+// stores a new empty board
+func (r *Repository) AddBoard(name string) error {
+	if err := ValidateBoardName(name); err != nil {
+		return err
+	}
+
+	return r.updateDatabase(func(database *databaseData) error {
+		if database.find(name) != -1 {
+			return errors.New(fmt.Sprintf(`Board "%v" already exists`, name))
+		}
+
+		database.Boards = append(database.Boards, boardData{Name: name})
+
+		return nil
+	})
+}
+
+func (r *Repository) RenameBoard(name, newName string) error {
+	if err := ValidateBoardName(newName); err != nil {
+		return err
+	}
+
+	return r.updateDatabase(func(database *databaseData) error {
+		index := database.find(name)
+
+		if index == -1 {
+			return errors.New(fmt.Sprintf(`Board "%v" not found`, name))
+		}
+
+		if database.find(newName) != -1 {
+			return errors.New(fmt.Sprintf(`Board "%v" already exists`, newName))
+		}
+
+		database.Boards[index].Name = newName
+
+		if database.ActiveBoard == name {
+			database.ActiveBoard = newName
+		}
+
+		return nil
+	})
+}
+
+// deletes a stored board with all its tasks, the active board cannot be deleted
+func (r *Repository) DeleteBoard(name string) error {
+	return r.updateDatabase(func(database *databaseData) error {
+		index := database.find(name)
+
+		if index == -1 {
+			return errors.New(fmt.Sprintf(`Board "%v" not found`, name))
+		}
+
+		if database.ActiveBoard == name {
+			return errors.New("The active board cannot be deleted, switch to another board first")
+		}
+
+		database.Boards = append(database.Boards[:index], database.Boards[index+1:]...)
+
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+package taskmanagement
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/marcos-venicius/daily-term/cycleparser"
+)
+
+func TestSaveBoardKeepsOtherBoards(t *testing.T) {
+	repository := createTestRepository(t)
+
+	main := CreateBoard()
+	main.AddTask("a")
+
+	if err := repository.SaveBoard(main); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.AddBoard("work"); err != nil {
+		t.Fatal(err)
+	}
+
+	work := CreateNamedBoard("work")
+	work.AddTask("b")
+
+	if err := repository.SaveBoard(work); err != nil {
+		t.Fatal(err)
+	}
+
+	loadedMain := CreateBoard()
+	repository.LoadBoard(loadedMain)
+
+	expectTaskNames(t, loadedMain.Tasks(), "a")
+
+	loadedWork := CreateNamedBoard("work")
+	repository.LoadBoard(loadedWork)
+
+	expectTaskNames(t, loadedWork.Tasks(), "b")
+}
+
+func TestActiveBoard(t *testing.T) {
+	repository := createTestRepository(t)
+
+	if name, _ := repository.ActiveBoardName(); name != DefaultBoardName {
+		t.Fatalf("Expected: %v, Received: %v", DefaultBoardName, name)
+	}
+
+	if err := repository.SetActiveBoard("home"); err == nil {
+		t.Fatal("Expected an error when the board does not exist")
+	}
+
+	repository.AddBoard("home")
+
+	if err := repository.SetActiveBoard("home"); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, _ := repository.ActiveBoardName(); name != "home" {
+		t.Fatalf("Expected: %v, Received: %v", "home", name)
+	}
+
+	if err := repository.DeleteBoard("home"); err == nil {
+		t.Fatal("Expected an error when deleting the active board")
+	}
+}
+
+func TestRenameAndDeleteBoards(t *testing.T) {
+	repository := createTestRepository(t)
+
+	repository.AddBoard("home")
+
+	if err := repository.AddBoard("home"); err == nil {
+		t.Fatal("Expected an error when the board already exists")
+	}
+
+	if err := repository.AddBoard("project x"); err == nil {
+		t.Fatal("Expected an error for an invalid board name")
+	}
+
+	if err := repository.RenameBoard(DefaultBoardName, "work"); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, _ := repository.ActiveBoardName(); name != "work" {
+		t.Fatalf("Expected: %v, Received: %v", "work", name)
+	}
+
+	if err := repository.DeleteBoard("home"); err != nil {
+		t.Fatal(err)
+	}
+
+	names, _ := repository.BoardNames()
+
+	if len(names) != 1 || names[0] != "work" {
+		t.Fatalf("Expected: %v, Received: %v", []string{"work"}, names)
+	}
+}
+
+func TestLoadSingleBoardDatabase(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	board.AddTask("a")
+
+	// how the database file was stored before multiple boards existed
+	v, err := cycleparser.ToValue(&struct {
+		Root          *Task `json:"root"`
+		PriorityOrder bool  `json:"priority_order"`
+	}{board.root, true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bytes, err := json.Marshal(v)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(repository.path, bytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := repository.BoardNames()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 1 || names[0] != DefaultBoardName {
+		t.Fatalf("Expected: %v, Received: %v", []string{DefaultBoardName}, names)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	expectTaskNames(t, loaded.Tasks(), "a")
+
+	if !loaded.PriorityOrder() {
+		t.Fatal("Expected the priority order to be loaded")
+	}
+}
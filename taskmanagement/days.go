@@ -0,0 +1,127 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// how many past days are kept for each board, older days are dropped when a new day starts
+// so the database (and every backup) does not grow forever
+const MaxStoredDays = 30
+
+// the tasks of a board as they were at the end of a day
+type dayData struct {
+	Date string `json:"date"` // formatted with DueDateLayout
+	Root *Task  `json:"root"`
+}
+
+// date of the day the board belongs to (formatted with DueDateLayout), empty before the first day starts
+func (board *Board) Day() string {
+	return board.day
+}
+
+// past days can be seen but not changed
+func (board *Board) IsReadOnly() bool {
+	return board.readOnly
+}
+
+// dates of the stored past days, oldest first
+func (board *Board) Days() []string {
+	var dates []string
+
+	for _, day := range board.days {
+		dates = append(dates, day.Date)
+	}
+
+	return dates
+}
+
+// when the board belongs to a day before now, that day is kept as it is and the board moves to the new day
+// carrying over only the unfinished tasks (finished subtasks of unfinished tasks are carried over too).
+// tells if the board changed and returns the finished tasks taken out of it, which must be archived
+// since past days are dropped after MaxStoredDays
+func (board *Board) StartDay(now time.Time) (bool, []*Task) {
+	today := now.Format(DueDateLayout)
+
+	if board.day == today {
+		return false, nil
+	}
+
+	var finished []*Task
+
+	if board.day != "" && board.day < today {
+		board.days = append(board.days, dayData{
+			Date: board.day,
+			Root: cloneTasks(board.root, nil),
+		})
+
+		if dropped := len(board.days) - MaxStoredDays; dropped > 0 {
+			board.days = append([]dayData{}, board.days[dropped:]...)
+		}
+
+		finished = board.takeFinishedTasks(nil, math.MaxInt64, nil)
+
+		for _, task := range finished {
+			board.recordSubtree(task, HistoryArchived)
+		}
+
+		board.ForgetUndoPoints()
+		board.fixSelectionAfterTaking()
+	}
+
+	board.day = today
+
+	return true, finished
+}
+
+// the last stored day before the current one
+func (board *Board) PreviousDay() (string, error) {
+	if len(board.days) == 0 {
+		return "", errors.New("There are no previous days")
+	}
+
+	return board.days[len(board.days)-1].Date, nil
+}
+
+// read-only board with the tasks of the given day (YYYY-MM-DD or today),
+// for the current day the board itself is returned
+func (board *Board) OpenDay(value string) (*Board, error) {
+	date := value
+
+	switch value {
+	case "today":
+		date = board.clock().Format(DueDateLayout)
+	default:
+		parsed, err := time.Parse(DueDateLayout, value)
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf(`Invalid date "%v", expected format is YYYY-MM-DD`, value))
+		}
+
+		date = parsed.Format(DueDateLayout)
+	}
+
+	if date == board.day {
+		return board, nil
+	}
+
+	for _, day := range board.days {
+		if day.Date != date {
+			continue
+		}
+
+		dayBoard := CreateNamedBoard(board.name)
+
+		dayBoard.workflow = board.workflow
+		dayBoard.root = cloneTasks(day.Root, nil)
+		dayBoard.task = dayBoard.root
+		dayBoard.day = date
+		dayBoard.readOnly = true
+
+		return dayBoard, nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("There is no board for %v", date))
+}
@@ -0,0 +1,135 @@
+package taskmanagement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartDayCarriesOverUnfinishedTasks(t *testing.T) {
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.clock = func() time.Time { return now }
+
+	if changed, _ := board.StartDay(now); !changed {
+		t.Fatal("Expected the first day to start")
+	}
+
+	board.AddTask("pending")
+	board.AddTask("done")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	if changed, _ := board.StartDay(now.Add(time.Hour)); changed {
+		t.Fatal("Expected the same day to not start again")
+	}
+
+	now = now.AddDate(0, 0, 1)
+
+	if changed, _ := board.StartDay(now); !changed {
+		t.Fatal("Expected a new day to start")
+	}
+
+	expectTaskNames(t, board.Tasks(), "pending")
+
+	if board.Day() != "2024-07-16" {
+		t.Fatalf("Expected: %v, Received: %v", "2024-07-16", board.Day())
+	}
+
+	previous, err := board.PreviousDay()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day, err := board.OpenDay(previous)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectTaskNames(t, day.Tasks(), "done", "pending")
+
+	if !day.IsReadOnly() {
+		t.Fatal("Expected a past day to be read-only")
+	}
+
+	if today, _ := board.OpenDay("today"); today != board {
+		t.Fatal("Expected today to be the board itself")
+	}
+
+	if _, err := board.OpenDay("2024-07-01"); err == nil {
+		t.Fatal("Expected an error for a day without board")
+	}
+}
+
+func TestStartDayReturnsFinishedTasksToArchive(t *testing.T) {
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.StartDay(now)
+
+	board.AddTask("pending")
+	done := board.AddTask("done")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	_, finished := board.StartDay(now.AddDate(0, 0, 1))
+
+	archive := CreateArchive()
+	archive.Add(finished, now)
+
+	expectArchivedNames(t, archive, "done")
+
+	if entries := board.TaskHistory(done.Id); entries[len(entries)-1].Kind != HistoryArchived {
+		t.Fatalf("Expected: %v, Received: %v", HistoryArchived, entries)
+	}
+}
+
+func TestPastDaysCannotBeSaved(t *testing.T) {
+	repository := createTestRepository(t)
+
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.StartDay(now)
+	board.AddTask("a")
+	board.StartDay(now.AddDate(0, 0, 1))
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	if len(loaded.Days()) != 1 {
+		t.Fatalf("Expected: %d, Received: %d", 1, len(loaded.Days()))
+	}
+
+	day, _ := loaded.OpenDay("2024-07-15")
+
+	if err := repository.SaveBoard(day); err == nil {
+		t.Fatal("Expected an error when saving a past day")
+	}
+}
+
+func TestStartDayKeepsOnlyRecentDays(t *testing.T) {
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.StartDay(now)
+
+	for i := 0; i < MaxStoredDays+5; i++ {
+		now = now.AddDate(0, 0, 1)
+		board.StartDay(now)
+	}
+
+	days := board.Days()
+
+	if len(days) != MaxStoredDays {
+		t.Fatalf("Expected: %d, Received: %d", MaxStoredDays, len(days))
+	}
+
+	if expected := now.AddDate(0, 0, -MaxStoredDays).Format(DueDateLayout); days[0] != expected {
+		t.Fatalf("Expected: %v, Received: %v", expected, days[0])
+	}
+}
@@ -0,0 +1,114 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (task *Task) DependsOn(id int) bool {
+	for _, blockerId := range task.BlockedBy {
+		if blockerId == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tells if "from" depends (directly or through other tasks) on the task with the given id
+func (board *Board) dependsOn(from *Task, id int, visited map[int]bool) bool {
+	if visited[from.Id] {
+		return false
+	}
+
+	visited[from.Id] = true
+
+	for _, blockerId := range from.BlockedBy {
+		if blockerId == id {
+			return true
+		}
+
+		if blocker := board.findTaskById(blockerId); blocker != nil && board.dependsOn(blocker, id, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the task with the given id cannot be finished until the blocker is
+func (board *Board) AddTaskDependency(id, blockerId int) error {
+	task := board.findTaskById(id)
+	blocker := board.findTaskById(blockerId)
+
+	if task == nil || blocker == nil {
+		return errors.New("Task not found")
+	}
+
+	if task == blocker {
+		return errors.New("A task cannot depend on itself")
+	}
+
+	if task.DependsOn(blockerId) {
+		return errors.New(fmt.Sprintf("Task %04d already depends on task %04d", id, blockerId))
+	}
+
+	if board.dependsOn(blocker, id, map[int]bool{}) {
+		return errors.New(fmt.Sprintf("Task %04d already depends on task %04d, it would be a circular dependency", blockerId, id))
+	}
+
+	board.saveUndoPoint()
+
+	task.BlockedBy = append(task.BlockedBy, blockerId)
+
+	return nil
+}
+
+func (board *Board) RemoveTaskDependency(id, blockerId int) error {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return errors.New("Task not found")
+	}
+
+	for index, current := range task.BlockedBy {
+		if current == blockerId {
+			board.saveUndoPoint()
+
+			task.BlockedBy = append(task.BlockedBy[:index:index], task.BlockedBy[index+1:]...)
+
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Task %04d does not depend on task %04d", id, blockerId))
+}
+
+// tasks in the board that block the given task and are not finished yet
+// (deleted or archived blockers do not block anymore)
+func (board *Board) OpenBlockers(task *Task) []Task {
+	var blockers []Task
+
+	for _, blockerId := range task.BlockedBy {
+		if blocker := board.findTaskById(blockerId); blocker != nil && !board.IsTaskDone(blocker) {
+			blockers = append(blockers, *blocker)
+		}
+	}
+
+	return blockers
+}
+
+func (board *Board) IsTaskBlocked(task *Task) bool {
+	return len(board.OpenBlockers(task)) > 0
+}
+
+func blockedError(blockers []Task) error {
+	var names []string
+
+	for _, blocker := range blockers {
+		names = append(names, fmt.Sprintf(`[%04d] "%v"`, blocker.Id, blocker.Name))
+	}
+
+	return errors.New(fmt.Sprintf("This task is blocked by %v", strings.Join(names, ", ")))
+}
@@ -0,0 +1,108 @@
+package taskmanagement
+
+import (
+	"testing"
+)
+
+func TestBlockedTaskCannotBeCompleted(t *testing.T) {
+	board := CreateBoard()
+
+	blocker := board.AddTask("blocker")
+	task := board.AddTask("task")
+
+	if err := board.AddTaskDependency(task.Id, blocker.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := board.MoveCurrentSelectedTaskTo(completed); err == nil {
+		t.Fatal("Expected a blocked task to not be completed")
+	}
+
+	// starting a blocked task is allowed
+	if _, err := board.MoveCurrentSelectedTaskTo(inProgress); err != nil {
+		t.Fatal(err)
+	}
+
+	board.SelectNextTask()
+	board.MoveCurrentSelectedTaskTo(completed)
+	board.SelectPreviousTask()
+
+	if _, err := board.MoveCurrentSelectedTaskTo(completed); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeletedBlockerDoesNotBlock(t *testing.T) {
+	board := CreateBoard()
+
+	blocker := board.AddTask("blocker")
+	task := board.AddTask("task")
+
+	board.AddTaskDependency(task.Id, blocker.Id)
+	board.DeleteTaskById(blocker.Id)
+
+	if board.IsTaskBlocked(board.CurrentTask()) {
+		t.Fatal("Expected the task to not be blocked")
+	}
+}
+
+func TestCircularDependenciesAreRefused(t *testing.T) {
+	board := CreateBoard()
+
+	a := board.AddTask("a")
+	b := board.AddTask("b")
+	c := board.AddTask("c")
+
+	if err := board.AddTaskDependency(a.Id, b.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := board.AddTaskDependency(b.Id, c.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := board.AddTaskDependency(c.Id, a.Id); err == nil {
+		t.Fatal("Expected a circular dependency to be refused")
+	}
+
+	if err := board.AddTaskDependency(a.Id, a.Id); err == nil {
+		t.Fatal("Expected a task to not depend on itself")
+	}
+}
+
+func TestRemoveTaskDependency(t *testing.T) {
+	board := CreateBoard()
+
+	blocker := board.AddTask("blocker")
+	task := board.AddTask("task")
+
+	board.AddTaskDependency(task.Id, blocker.Id)
+
+	if err := board.RemoveTaskDependency(task.Id, blocker.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := board.RemoveTaskDependency(task.Id, blocker.Id); err == nil {
+		t.Fatal("Expected an error when the dependency does not exist")
+	}
+}
+
+func TestSaveAndLoadBoardKeepsDependencies(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	blocker := board.AddTask("blocker")
+	task := board.AddTask("task")
+	board.AddTaskDependency(task.Id, blocker.Id)
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	if !loaded.IsTaskBlocked(loaded.CurrentTask()) {
+		t.Fatal("Expected the loaded task to be blocked")
+	}
+}
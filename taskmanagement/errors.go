@@ -0,0 +1,49 @@
+package taskmanagement
+
+import (
+	"fmt"
+)
+
+// the database (or archive) file exists but could not be decoded
+type InvalidDatabaseError struct {
+	File string
+	Err  error
+}
+
+func (e *InvalidDatabaseError) Error() string {
+	return fmt.Sprintf("%v is corrupt: %v", e.File, e.Err.Error())
+}
+
+func (e *InvalidDatabaseError) Unwrap() error {
+	return e.Err
+}
+
+// the database file was written by a newer version of the application
+type SchemaVersionError struct {
+	Version int
+}
+
+func (e *SchemaVersionError) Error() string {
+	return fmt.Sprintf("The database was written by a newer version of daily-term (schema %d, supported %d), update daily-term to open it", e.Version, schemaVersion)
+}
+
+// tells how a corrupt file was recovered, the corrupt file is kept in MovedTo.
+// Backup is the number of the backup that replaced it, 0 when there was no backup to use and the file starts empty
+type CorruptDatabaseError struct {
+	File    string
+	MovedTo string
+	Backup  int
+	Err     error
+}
+
+func (e *CorruptDatabaseError) Error() string {
+	if e.Backup == 0 {
+		return fmt.Sprintf("%v was corrupt (%v), it was moved to %v and starts empty", e.File, e.Err.Error(), e.MovedTo)
+	}
+
+	return fmt.Sprintf("%v was corrupt (%v), it was moved to %v and backup %d was restored", e.File, e.Err.Error(), e.MovedTo, e.Backup)
+}
+
+func (e *CorruptDatabaseError) Unwrap() error {
+	return e.Err
+}
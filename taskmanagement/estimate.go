@@ -0,0 +1,134 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// effort of a task, either in points or in minutes (never both)
+type Estimate struct {
+	Points  int
+	Minutes int
+}
+
+func (estimate Estimate) IsZero() bool {
+	return estimate.Points == 0 && estimate.Minutes == 0
+}
+
+func (estimate Estimate) add(other Estimate) Estimate {
+	return Estimate{
+		Points:  estimate.Points + other.Points,
+		Minutes: estimate.Minutes + other.Minutes,
+	}
+}
+
+// "13pts", "2h30m", "13pts 2h30m" or "0" when there is no estimate
+func (estimate Estimate) String() string {
+	var parts []string
+
+	if estimate.Points > 0 {
+		parts = append(parts, fmt.Sprintf("%dpts", estimate.Points))
+	}
+
+	if estimate.Minutes > 0 {
+		parts = append(parts, FormatDuration(time.Duration(estimate.Minutes)*time.Minute, false))
+	}
+
+	if len(parts) == 0 {
+		return "0"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// accepts points ("5", "5pts"), hours and minutes ("2h", "1h30m", "45m") and "none" (or "0") to remove the estimate
+func ParseEstimate(value string) (Estimate, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if value == "none" || value == "0" {
+		return Estimate{}, nil
+	}
+
+	invalid := errors.New(fmt.Sprintf(`Invalid estimate "%v", expected points (5, 5pts), hours (2h, 1h30m, 45m) or none`, value))
+
+	if points, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSuffix(value, "pts"), "pt")); err == nil {
+		if points < 0 {
+			return Estimate{}, invalid
+		}
+
+		return Estimate{Points: points}, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+
+	if err != nil || duration <= 0 || duration%time.Minute != 0 {
+		return Estimate{}, invalid
+	}
+
+	return Estimate{Minutes: int(duration.Minutes())}, nil
+}
+
+func (task *Task) Estimate() Estimate {
+	return Estimate{
+		Points:  task.EstimatePoints,
+		Minutes: task.EstimateMinutes,
+	}
+}
+
+// sets the estimate of the task with the given id and returns the previous one
+func (board *Board) SetTaskEstimate(id int, estimate Estimate) (Estimate, error) {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return Estimate{}, errors.New("Task not found")
+	}
+
+	if estimate.Points > 0 && estimate.Minutes > 0 {
+		return Estimate{}, errors.New("A task is estimated either in points or in hours")
+	}
+
+	board.saveUndoPoint()
+
+	previous := task.Estimate()
+
+	task.EstimatePoints = estimate.Points
+	task.EstimateMinutes = estimate.Minutes
+
+	return previous, nil
+}
+
+// sum of the estimates of the tasks in a state
+type StateEstimate struct {
+	State    StateDefinition
+	Estimate Estimate
+}
+
+// sum of the estimates of all tasks (subtasks included) for every state, in the workflow order.
+// it is empty when no task is estimated
+func (board *Board) EstimateTotals() []StateEstimate {
+	var totals []StateEstimate
+
+	estimated := false
+
+	for _, state := range board.workflow.States() {
+		total := StateEstimate{State: state}
+
+		for _, task := range board.collectTasks(board.root, false, nil) {
+			if task.State == state.Id {
+				total.Estimate = total.Estimate.add(task.Estimate())
+			}
+		}
+
+		estimated = estimated || !total.Estimate.IsZero()
+		totals = append(totals, total)
+	}
+
+	if !estimated {
+		return nil
+	}
+
+	return totals
+}
@@ -0,0 +1,69 @@
+package taskmanagement
+
+import (
+	"testing"
+)
+
+func TestParseEstimate(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected Estimate
+	}{
+		{"5", Estimate{Points: 5}},
+		{"13pts", Estimate{Points: 13}},
+		{"2h", Estimate{Minutes: 120}},
+		{"1h30m", Estimate{Minutes: 90}},
+		{"45m", Estimate{Minutes: 45}},
+		{"none", Estimate{}},
+	}
+
+	for _, c := range cases {
+		estimate, err := ParseEstimate(c.value)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if estimate != c.expected {
+			t.Fatalf("Expected: %v, Received: %v", c.expected, estimate)
+		}
+	}
+
+	for _, value := range []string{"-3", "abc", "30s", "1.5"} {
+		if _, err := ParseEstimate(value); err == nil {
+			t.Fatalf("Expected an error for %v", value)
+		}
+	}
+}
+
+func TestEstimateTotals(t *testing.T) {
+	board := CreateBoard()
+
+	if totals := board.EstimateTotals(); totals != nil {
+		t.Fatalf("Expected no totals, Received: %v", totals)
+	}
+
+	a := board.AddTask("a")
+	board.SetTaskEstimate(a.Id, Estimate{Points: 8})
+
+	b, _ := board.AddSubtask("b")
+	board.SetTaskEstimate(b.Id, Estimate{Points: 5})
+
+	c := board.AddTask("c")
+	board.SetTaskEstimate(c.Id, Estimate{Minutes: 90})
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	totals := board.EstimateTotals()
+
+	expected := []string{"13pts", "0", "1h30m"}
+
+	if len(totals) != len(expected) {
+		t.Fatalf("Expected: %d, Received: %d", len(expected), len(totals))
+	}
+
+	for index, total := range totals {
+		if total.Estimate.String() != expected[index] {
+			t.Fatalf("Expected: %v, Received: %v", expected[index], total.Estimate.String())
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writes the bytes into a temporary file next to the destination and renames it into place,
+// so a crash or a full disk leaves either the old or the new content, never a truncated file
+func writeFileAtomically(path string, bytes []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(temp.Name())
+
+	l, err := temp.Write(bytes)
+
+	if err == nil && l != len(bytes) {
+		err = errors.New(fmt.Sprintf("Could not write %v, wrote %d of %d bytes", filepath.Base(path), l, len(bytes)))
+	}
+
+	if err == nil {
+		err = temp.Chmod(0600)
+	}
+
+	if err == nil {
+		err = temp.Sync()
+	}
+
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.Rename(temp.Name(), path); err != nil {
+		return err
+	}
+
+	syncFolder(filepath.Dir(path))
+
+	return nil
+}
+
+// makes the rename durable, not every platform can sync a folder so errors are ignored
+func syncFolder(path string) {
+	folder, err := os.Open(path)
+
+	if err != nil {
+		return
+	}
+
+	folder.Sync()
+	folder.Close()
+}
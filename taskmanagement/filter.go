@@ -0,0 +1,86 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// shows only the tasks in the state with the given name, an empty name removes the filter
+func (board *Board) SetStateFilter(name string) error {
+	if name == "" {
+		board.stateFilter = nil
+	} else {
+		state, ok := board.workflow.StateByName(name)
+
+		if !ok {
+			return errors.New(fmt.Sprintf(`Unknown state "%v"`, name))
+		}
+
+		board.stateFilter = &state.Id
+	}
+
+	board.ensureVisibleSelection()
+
+	return nil
+}
+
+// name of the state being filtered, empty when there is no state filter
+func (board *Board) StateFilter() string {
+	if board.stateFilter == nil {
+		return ""
+	}
+
+	return board.workflow.Name(*board.stateFilter)
+}
+
+// shows only the tasks with the given text in their name or notes (ignoring case), an empty text removes the filter
+func (board *Board) SetTextFilter(text string) {
+	board.textFilter = strings.TrimSpace(text)
+	board.ensureVisibleSelection()
+}
+
+func (board *Board) TextFilter() string {
+	return board.textFilter
+}
+
+// removes the tag, state and text filters
+func (board *Board) ClearFilters() {
+	board.tagFilter = ""
+	board.stateFilter = nil
+	board.textFilter = ""
+	board.ensureVisibleSelection()
+}
+
+// applies the tag, state and text filters of another board, so they are kept when the board is reloaded
+func (board *Board) CopyFilters(from *Board) {
+	board.tagFilter = from.tagFilter
+	board.stateFilter = nil
+	board.textFilter = from.textFilter
+
+	if from.stateFilter != nil {
+		state := *from.stateFilter
+		board.stateFilter = &state
+	}
+
+	board.ensureVisibleSelection()
+}
+
+// tells if the task passes the tag, state and text filters
+func (board *Board) passesFilters(task *Task) bool {
+	if board.tagFilter != "" && !task.HasTag(board.tagFilter) {
+		return false
+	}
+
+	if board.stateFilter != nil && task.State != *board.stateFilter {
+		return false
+	}
+
+	if board.textFilter != "" {
+		if !task.NameContains(board.textFilter) && !strings.Contains(strings.ToLower(task.Notes), strings.ToLower(board.textFilter)) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,151 @@
+package taskmanagement
+
+import (
+	"testing"
+)
+
+func TestStateFilter(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.AddTask("b")
+	board.MoveCurrentSelectedTaskTo(inProgress)
+	board.AddTask("c")
+
+	if err := board.SetStateFilter("inprogress"); err != nil {
+		t.Fatal(err)
+	}
+
+	expectTaskNames(t, board.VisibleTasks(), "b")
+
+	if board.CurrentTask().Name != "b" {
+		t.Fatalf("Expected: %v, Received: %v", "b", board.CurrentTask().Name)
+	}
+
+	if board.StateFilter() != "In progress" {
+		t.Fatalf("Expected: %v, Received: %v", "In progress", board.StateFilter())
+	}
+
+	// a task that leaves the filtered state is hidden
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	if board.CurrentTask() != nil {
+		t.Fatalf("Expected no selected task, Received: %v", board.CurrentTask().Name)
+	}
+
+	if err := board.SetStateFilter("waiting"); err == nil {
+		t.Fatal("Expected an error for an unknown state")
+	}
+}
+
+func TestTextFilterSkipsHiddenTasks(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("deploy api")
+	board.AddTask("write docs")
+	notes := board.AddTask("release")
+	board.AppendTaskNote(notes.Id, "needs a DEPLOY first")
+
+	board.SetTextFilter("Deploy")
+
+	expectTaskNames(t, board.VisibleTasks(), "release", "deploy api")
+
+	board.SelectNextTask()
+
+	if board.CurrentTask().Name != "deploy api" {
+		t.Fatalf("Expected: %v, Received: %v", "deploy api", board.CurrentTask().Name)
+	}
+
+	board.SetStateFilter("todo")
+	board.SetTagFilter("work")
+
+	board.ClearFilters()
+
+	if board.TagFilter() != "" || board.StateFilter() != "" || board.TextFilter() != "" {
+		t.Fatal("Expected all filters to be removed")
+	}
+
+	expectTaskNames(t, board.VisibleTasks(), "release", "write docs", "deploy api")
+}
+
+func TestMoveTaskOutOfStateFilterReturnsMovedTask(t *testing.T) {
+	board := CreateBoard()
+
+	a := board.AddTask("a")
+	b := board.AddTask("b")
+
+	if err := board.SetStateFilter("todo"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the selection moves to the other visible task
+	moved, err := board.MoveCurrentSelectedTaskTo(completed)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if moved.Id != b.Id || moved.State != completed {
+		t.Fatalf("Expected: %v, Received: %v", b.Name, moved.Name)
+	}
+
+	if board.CurrentTask() == nil || board.CurrentTask().Id != a.Id {
+		t.Fatalf("Expected: %v, Received: %v", a.Name, board.CurrentTask())
+	}
+
+	// and to no task when the last visible task is moved
+	moved, err = board.MoveCurrentSelectedTaskTo(completed)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if moved.Id != a.Id {
+		t.Fatalf("Expected: %v, Received: %v", a.Name, moved.Name)
+	}
+
+	if board.CurrentTask() != nil {
+		t.Fatalf("Expected no selected task, Received: %v", board.CurrentTask().Name)
+	}
+
+	if _, err = board.MoveCurrentSelectedTaskTo(inProgress); err == nil {
+		t.Fatal("Expected an error without a selected task")
+	}
+}
+
+func TestFiltersSurviveReload(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.AddTask("deploy api")
+	board.AddTaskTag(board.CurrentTask().Id, "work")
+	board.MoveCurrentSelectedTaskTo(inProgress)
+	board.AddTask("deploy docs")
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	board.SetTagFilter("work")
+	board.SetStateFilter("inprogress")
+	board.SetTextFilter("deploy")
+
+	reloaded := CreateBoard()
+	repository.LoadBoard(reloaded)
+	reloaded.CopyFilters(board)
+
+	expectTaskNames(t, reloaded.VisibleTasks(), "deploy api")
+
+	if reloaded.TagFilter() != "work" || reloaded.StateFilter() != "In progress" || reloaded.TextFilter() != "deploy" {
+		t.Fatalf("Expected: %v, Received: %v", []string{"work", "In progress", "deploy"}, []string{reloaded.TagFilter(), reloaded.StateFilter(), reloaded.TextFilter()})
+	}
+
+	// the filters are copied, changing them on the reloaded board does not change the old one
+	reloaded.ClearFilters()
+
+	if board.StateFilter() != "In progress" {
+		t.Fatalf("Expected: %v, Received: %v", "In progress", board.StateFilter())
+	}
+}
@@ -0,0 +1,67 @@
+package taskmanagement
+
+import (
+	"fmt"
+)
+
+// these are all kinds of history entries
+const (
+	HistoryCreated  = "created"
+	HistoryRenamed  = "renamed"
+	HistoryState    = "state"
+	HistoryDeleted  = "deleted"
+	HistoryArchived = "archived"
+	HistoryRestored = "restored"
+)
+
+// something that happened to a task
+type HistoryEntry struct {
+	TaskId int    `json:"task_id"`
+	Kind   string `json:"kind"`
+	From   string `json:"from"` // previous name or state name, when it applies
+	To     string `json:"to"`   // new name or state name, when it applies
+	At     int64  `json:"at"`   // unix timestamp
+}
+
+func (entry HistoryEntry) Describe() string {
+	switch entry.Kind {
+	case HistoryCreated:
+		return fmt.Sprintf(`created "%v"`, entry.To)
+	case HistoryRenamed:
+		return fmt.Sprintf(`renamed "%v" to "%v"`, entry.From, entry.To)
+	case HistoryState:
+		return fmt.Sprintf("%v → %v", entry.From, entry.To)
+	default:
+		return entry.Kind
+	}
+}
+
+func (board *Board) record(task *Task, kind, from, to string) {
+	board.history = append(board.history, HistoryEntry{
+		TaskId: task.Id,
+		Kind:   kind,
+		From:   from,
+		To:     to,
+		At:     board.clock().Unix(),
+	})
+}
+
+// records the same entry for the task and all its subtasks
+func (board *Board) recordSubtree(task *Task, kind string) {
+	for _, current := range collectSubtree(task) {
+		board.record(current, kind, "", "")
+	}
+}
+
+// history of the task with the given id (it may be deleted already), oldest first
+func (board *Board) TaskHistory(id int) []HistoryEntry {
+	var entries []HistoryEntry
+
+	for _, entry := range board.history {
+		if entry.TaskId == id {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
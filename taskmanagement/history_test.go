@@ -0,0 +1,106 @@
+package taskmanagement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistoryRecordsTaskLifecycle(t *testing.T) {
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.clock = func() time.Time { return now }
+
+	task := board.AddTask("a")
+
+	now = now.Add(time.Hour)
+
+	board.MoveCurrentSelectedTaskTo(inProgress)
+	board.MoveCurrentSelectedTaskTo(completed)
+	board.AddTask("b")
+	board.DeleteTaskById(task.Id)
+
+	entries := board.TaskHistory(task.Id)
+
+	expected := []string{`created "a"`, "Todo → In progress", "In progress → Completed", "deleted"}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected: %d, Received: %d", len(expected), len(entries))
+	}
+
+	for index, entry := range entries {
+		if entry.Describe() != expected[index] {
+			t.Fatalf("Expected: %v, Received: %v", expected[index], entry.Describe())
+		}
+	}
+
+	if entries[1].At != now.Unix() {
+		t.Fatalf("Expected: %v, Received: %v", now.Unix(), entries[1].At)
+	}
+}
+
+func TestHistoryRecordsDeletedSubtasks(t *testing.T) {
+	board := CreateBoard()
+
+	parent := board.AddTask("parent")
+	child, _ := board.AddSubtask("child")
+
+	board.DeleteTaskById(parent.Id)
+
+	entries := board.TaskHistory(child.Id)
+
+	if len(entries) != 2 || entries[1].Kind != HistoryDeleted {
+		t.Fatalf("Expected: %v, Received: %v", HistoryDeleted, entries)
+	}
+}
+
+func TestUndoKeepsHistory(t *testing.T) {
+	board := CreateBoard()
+
+	task := board.AddTask("a")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	if err := board.Undo(); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries := board.TaskHistory(task.Id); len(entries) != 2 {
+		t.Fatalf("Expected: %d, Received: %d", 2, len(entries))
+	}
+}
+
+func TestRevertLastChangeDropsItsHistory(t *testing.T) {
+	board := CreateBoard()
+
+	task := board.AddTask("a")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	if err := board.RevertLastChange(); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries := board.TaskHistory(task.Id); len(entries) != 1 {
+		t.Fatalf("Expected: %d, Received: %d", 1, len(entries))
+	}
+}
+
+func TestSaveAndLoadBoardKeepsHistory(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	task := board.AddTask("a")
+	board.MoveCurrentSelectedTaskTo(completed)
+	board.AddTask("b")
+	board.DeleteTaskById(task.Id)
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	if entries := loaded.TaskHistory(task.Id); len(entries) != 3 {
+		t.Fatalf("Expected: %d, Received: %d", 3, len(entries))
+	}
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+package taskmanagement
+
+import (
+	"os"
+	"syscall"
+)
+
+// takes an advisory lock shared by every instance using the same database, waiting while another instance holds it
+func (r *Repository) lock() (func(), error) {
+	file, err := os.OpenFile(r.path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}, nil
+}
@@ -0,0 +1,50 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var priorityNames = map[TaskPriority]string{
+	PriorityNone:   "none",
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+func (priority TaskPriority) String() string {
+	if name, ok := priorityNames[priority]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// short marker shown before the task name (empty when the task has no priority)
+func (priority TaskPriority) Marker() string {
+	if priority <= PriorityNone || priority > PriorityUrgent {
+		return ""
+	}
+
+	return strings.Repeat("!", int(priority))
+}
+
+// parses a priority by its name (none, low, medium, high, urgent) or by its level (0 to 4)
+func ParseTaskPriority(value string) (TaskPriority, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	for priority, name := range priorityNames {
+		if name == value {
+			return priority, nil
+		}
+	}
+
+	if level, err := strconv.Atoi(value); err == nil && level >= int(PriorityNone) && level <= int(PriorityUrgent) {
+		return TaskPriority(level), nil
+	}
+
+	return PriorityNone, errors.New(fmt.Sprintf(`Invalid priority "%v", expected none, low, medium, high or urgent`, value))
+}
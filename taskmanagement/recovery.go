@@ -0,0 +1,85 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// moves a corrupt database aside (database.json.corrupt-<timestamp>) and replaces it with the newest backup that can be read.
+// returns nil when the database is not corrupt and a *CorruptDatabaseError telling what was done when it was,
+// any other error means the recovery itself failed
+func (r *Repository) RecoverDatabase() error {
+	unlock, err := r.lock()
+
+	if err != nil {
+		return errors.New(fmt.Sprintf("Could not lock the database: %v", err.Error()))
+	}
+
+	defer unlock()
+
+	bytes, err := os.ReadFile(r.path)
+
+	if os.IsNotExist(err) || (err == nil && len(bytes) == 0) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = decodeDatabase(bytes)
+
+	invalid, ok := err.(*InvalidDatabaseError)
+
+	if !ok {
+		return err
+	}
+
+	corrupt, err := moveCorruptFile(r.path, invalid)
+
+	if err != nil {
+		return err
+	}
+
+	for number := 1; number <= MaxBackups; number++ {
+		bytes, err := os.ReadFile(r.backupPath(number))
+
+		if err != nil {
+			continue
+		}
+
+		if _, err = decodeDatabase(bytes); err != nil {
+			continue
+		}
+
+		if err = writeFileAtomically(r.path, bytes); err != nil {
+			return errors.New(fmt.Sprintf("%v, but backup %d could not be restored: %v", corrupt.Error(), number, err.Error()))
+		}
+
+		corrupt.Backup = number
+
+		break
+	}
+
+	r.stamp = readFileStamp(r.path)
+
+	return corrupt
+}
+
+// keeps the corrupt file as <file>.corrupt-<timestamp>, so nothing is written over it
+func moveCorruptFile(path string, invalid *InvalidDatabaseError) (*CorruptDatabaseError, error) {
+	corrupt := &CorruptDatabaseError{
+		File:    filepath.Base(path),
+		MovedTo: fmt.Sprintf("%v.corrupt-%v", path, time.Now().Format("20060102-150405")),
+		Err:     invalid.Err,
+	}
+
+	if err := os.Rename(path, corrupt.MovedTo); err != nil {
+		return nil, errors.New(fmt.Sprintf("%v, and it could not be moved aside: %v", invalid.Error(), err.Error()))
+	}
+
+	return corrupt, nil
+}
@@ -0,0 +1,245 @@
+package taskmanagement
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func corruptDatabase(t *testing.T, repository *Repository, content string) {
+	if err := os.WriteFile(repository.path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func corruptFiles(t *testing.T, repository *Repository) []string {
+	files, err := filepath.Glob(repository.path + ".corrupt-*")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files
+}
+
+func TestLoadBoardRecoversFromBackup(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+	saveTaskNames(t, repository, "a", "b")
+
+	corruptDatabase(t, repository, `{"version": 2, "data": {"kind": "ptr"`)
+
+	loaded := CreateBoard()
+	err := repository.LoadBoard(loaded)
+
+	corrupt, ok := err.(*CorruptDatabaseError)
+
+	if !ok {
+		t.Fatalf("Expected: %v, Received: %v", "*CorruptDatabaseError", err)
+	}
+
+	if corrupt.Backup != 1 {
+		t.Fatalf("Expected: %d, Received: %d", 1, corrupt.Backup)
+	}
+
+	expectTaskNames(t, loaded.Tasks(), "a")
+
+	files := corruptFiles(t, repository)
+
+	if len(files) != 1 || files[0] != corrupt.MovedTo {
+		t.Fatalf("Expected: %v, Received: %v", []string{corrupt.MovedTo}, files)
+	}
+}
+
+func TestLoadBoardRecoversFromMalformedValues(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+	saveTaskNames(t, repository, "a", "b")
+
+	// a pointer without refid
+	corruptDatabase(t, repository, `{"version": 2, "data": {"kind": "ptr", "refid": 1, "value": {"kind": "struct"}}}`)
+
+	loaded := CreateBoard()
+
+	if _, ok := repository.LoadBoard(loaded).(*CorruptDatabaseError); !ok {
+		t.Fatalf("Expected: %v, Received: %v", "*CorruptDatabaseError", "other error")
+	}
+}
+
+func TestLoadBoardWithoutBackupStartsEmpty(t *testing.T) {
+	repository := createTestRepository(t)
+
+	corruptDatabase(t, repository, "not json")
+
+	loaded := CreateBoard()
+	err := repository.LoadBoard(loaded)
+
+	corrupt, ok := err.(*CorruptDatabaseError)
+
+	if !ok {
+		t.Fatalf("Expected: %v, Received: %v", "*CorruptDatabaseError", err)
+	}
+
+	if corrupt.Backup != 0 {
+		t.Fatalf("Expected: %d, Received: %d", 0, corrupt.Backup)
+	}
+
+	if loaded.HasTasks() {
+		t.Fatalf("Expected: %v, Received: %v", false, true)
+	}
+
+	bytes, err := os.ReadFile(corrupt.MovedTo)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(bytes) != "not json" {
+		t.Fatalf("Expected: %v, Received: %v", "not json", string(bytes))
+	}
+
+	// the board can be saved again
+	loaded.AddTask("a")
+
+	if err = repository.SaveBoard(loaded); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecoverDatabaseKeepsValidDatabase(t *testing.T) {
+	repository := createTestRepository(t)
+
+	if err := repository.RecoverDatabase(); err != nil {
+		t.Fatal(err)
+	}
+
+	saveTaskNames(t, repository, "a")
+
+	if err := repository.RecoverDatabase(); err != nil {
+		t.Fatal(err)
+	}
+
+	if files := corruptFiles(t, repository); len(files) != 0 {
+		t.Fatalf("Expected: %v, Received: %v", []string{}, files)
+	}
+}
+
+func TestNewerSchemaIsNotCorrupt(t *testing.T) {
+	repository := createTestRepository(t)
+
+	corruptDatabase(t, repository, `{"version": 99, "data": {"kind": "ptr", "refid": 1, "value": null}}`)
+
+	err := repository.LoadBoard(CreateBoard())
+
+	if _, ok := err.(*SchemaVersionError); !ok {
+		t.Fatalf("Expected: %v, Received: %v", "*SchemaVersionError", err)
+	}
+
+	if !strings.Contains(err.Error(), "newer version") {
+		t.Fatalf("Expected: %v, Received: %v", "newer version", err.Error())
+	}
+
+	if files := corruptFiles(t, repository); len(files) != 0 {
+		t.Fatalf("Expected: %v, Received: %v", []string{}, files)
+	}
+}
+
+func TestLoadBoardReportsCorruptFileThatCannotBeMoved(t *testing.T) {
+	repository := createTestRepository(t)
+
+	corruptDatabase(t, repository, "not json")
+
+	// the corrupt file cannot be renamed over a folder
+	now := time.Now()
+
+	for _, at := range []time.Time{now, now.Add(time.Second)} {
+		if err := os.Mkdir(repository.path+".corrupt-"+at.Format("20060102-150405"), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := repository.LoadBoard(CreateBoard())
+
+	if err == nil || !strings.Contains(err.Error(), "could not be moved aside") {
+		t.Fatalf("Expected: %v, Received: %v", "could not be moved aside", err)
+	}
+
+	bytes, _ := os.ReadFile(repository.path)
+
+	if string(bytes) != "not json" {
+		t.Fatalf("Expected: %v, Received: %v", "not json", string(bytes))
+	}
+}
+
+func TestLoadArchiveRecoversFromMalformedValues(t *testing.T) {
+	repository := createTestRepository(t)
+
+	payload := `{"kind": "ptr", "refid": 1, "value": {"kind": "struct"}}`
+
+	if err := os.WriteFile(repository.archivePath, []byte(payload), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := repository.LoadArchive()
+
+	corrupt, ok := err.(*CorruptDatabaseError)
+
+	if !ok {
+		t.Fatalf("Expected: %v, Received: %v", "*CorruptDatabaseError", err)
+	}
+
+	if !archive.IsEmpty() {
+		t.Fatalf("Expected: %v, Received: %v", true, false)
+	}
+
+	if !strings.HasPrefix(filepath.Base(corrupt.MovedTo), archiveName+".corrupt-") {
+		t.Fatalf("Expected: %v, Received: %v", archiveName+".corrupt-<timestamp>", corrupt.MovedTo)
+	}
+
+	// the new archive is written without touching the corrupt one
+	archive.Add([]*Task{{Id: 1, Name: "a"}}, time.Now())
+
+	if err = repository.SaveArchive(archive); err != nil {
+		t.Fatal(err)
+	}
+
+	bytes, err := os.ReadFile(corrupt.MovedTo)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(bytes) != payload {
+		t.Fatalf("Expected: %v, Received: %v", payload, string(bytes))
+	}
+}
+
+func TestSaveArchiveDoesNotOverwriteCorruptArchive(t *testing.T) {
+	repository := createTestRepository(t)
+
+	archive, err := repository.LoadArchive()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(repository.archivePath, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	archive.Add([]*Task{{Id: 1, Name: "a"}}, time.Now())
+
+	if _, ok := repository.SaveArchive(archive).(*InvalidDatabaseError); !ok {
+		t.Fatalf("Expected: %v, Received: %v", "*InvalidDatabaseError", "other error")
+	}
+
+	bytes, _ := os.ReadFile(repository.archivePath)
+
+	if string(bytes) != "not json" {
+		t.Fatalf("Expected: %v, Received: %v", "not json", string(bytes))
+	}
+}
@@ -0,0 +1,253 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// canonical recurrence rules, weekly and monthly rules carry their weekday or day of the month,
+// for example "weekly:monday" or "monthly:1"
+const (
+	RecurrenceDaily    = "daily"
+	RecurrenceWeekdays = "weekdays"
+	RecurrenceWeekly   = "weekly"
+	RecurrenceMonthly  = "monthly"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func parseWeekday(value string) (time.Weekday, bool) {
+	for name, weekday := range weekdays {
+		if name == value || (len(value) >= 3 && strings.HasPrefix(name, value)) {
+			return weekday, true
+		}
+	}
+
+	return time.Sunday, false
+}
+
+// validates a recurrence typed by the user and returns its canonical form.
+// accepted values are "daily", "weekdays", a weekday name (like "monday" or "weekly:mon"),
+// "monthly:<day>" and "none" (which removes the recurrence)
+func ParseRecurrence(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch value {
+	case "none", "":
+		return "", nil
+	case "daily", "day", "everyday":
+		return RecurrenceDaily, nil
+	case "weekdays", "weekday":
+		return RecurrenceWeekdays, nil
+	}
+
+	kind, argument, found := strings.Cut(value, ":")
+
+	if !found {
+		kind, argument = RecurrenceWeekly, value
+	}
+
+	switch kind {
+	case RecurrenceWeekly:
+		if weekday, ok := parseWeekday(argument); ok {
+			return fmt.Sprintf("%v:%v", RecurrenceWeekly, strings.ToLower(weekday.String())), nil
+		}
+	case RecurrenceMonthly:
+		if day, err := strconv.Atoi(argument); err == nil && day >= 1 && day <= 31 {
+			return fmt.Sprintf("%v:%d", RecurrenceMonthly, day), nil
+		}
+	}
+
+	return "", errors.New(fmt.Sprintf(`Invalid recurrence "%v", expected daily, weekdays, <weekday> or monthly:<day>`, value))
+}
+
+// human readable form of a canonical recurrence
+func DescribeRecurrence(recurrence string) string {
+	kind, argument, _ := strings.Cut(recurrence, ":")
+
+	switch kind {
+	case RecurrenceDaily:
+		return "every day"
+	case RecurrenceWeekdays:
+		return "every weekday"
+	case RecurrenceWeekly:
+		return fmt.Sprintf("every %v", argument)
+	case RecurrenceMonthly:
+		return fmt.Sprintf("every month on day %v", argument)
+	default:
+		return "never"
+	}
+}
+
+func recurrenceMatches(recurrence string, date time.Time) bool {
+	kind, argument, _ := strings.Cut(recurrence, ":")
+
+	switch kind {
+	case RecurrenceDaily:
+		return true
+	case RecurrenceWeekdays:
+		return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+	case RecurrenceWeekly:
+		weekday, ok := parseWeekday(argument)
+
+		return ok && date.Weekday() == weekday
+	case RecurrenceMonthly:
+		day, err := strconv.Atoi(argument)
+
+		if err != nil {
+			return false
+		}
+
+		// months without that day repeat on their last day
+		lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+
+		return date.Day() == day || (day > lastDay && date.Day() == lastDay)
+	default:
+		return false
+	}
+}
+
+// first day, strictly after "after", in which the recurrence happens
+func nextOccurrence(recurrence string, after time.Time) (time.Time, error) {
+	date := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, after.Location())
+
+	for i := 0; i < 366; i++ {
+		date = date.AddDate(0, 0, 1)
+
+		if recurrenceMatches(recurrence, date) {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, errors.New(fmt.Sprintf(`Recurrence "%v" never happens`, recurrence))
+}
+
+func (task *Task) IsRecurring() bool {
+	return task.Recurrence != ""
+}
+
+func startOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// sets the recurrence of the task with the given id and returns the previous one.
+// a recurring task without due date gets its first occurrence (from today on) as due date
+func (board *Board) SetTaskRecurrence(id int, value string, now time.Time) (string, error) {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return "", errors.New("Task not found")
+	}
+
+	recurrence, err := ParseRecurrence(value)
+
+	if err != nil {
+		return "", err
+	}
+
+	dueDate := task.DueDate
+
+	if recurrence != "" && !task.HasDueDate() {
+		first, err := nextOccurrence(recurrence, startOfDay(now).AddDate(0, 0, -1))
+
+		if err != nil {
+			return "", err
+		}
+
+		dueDate = first.Format(DueDateLayout)
+	}
+
+	board.saveUndoPoint()
+
+	task.DueDate = dueDate
+
+	previousRecurrence := task.Recurrence
+	task.Recurrence = recurrence
+
+	return previousRecurrence, nil
+}
+
+// creates the next instance of a recurring task, due at its first occurrence after "after".
+// the recurrence moves to the new instance, so every recurrence has a single pending instance
+func (board *Board) createNextOccurrence(task *Task, after time.Time) (*Task, error) {
+	due, err := nextOccurrence(task.Recurrence, after)
+
+	if err != nil {
+		return nil, err
+	}
+
+	next := &Task{
+		Id:         board.idCluster.NewId(),
+		Name:       task.Name,
+		State:      board.workflow.Initial(),
+		Priority:   task.Priority,
+		DueDate:    due.Format(DueDateLayout),
+		Tags:       append([]string{}, task.Tags...),
+		Notes:      task.Notes,
+		Recurrence: task.Recurrence,
+		CreatedAt:  board.clock().Unix(),
+	}
+
+	board.insertTaskBefore(next, task)
+
+	board.record(next, HistoryCreated, "", next.Name)
+
+	task.Recurrence = ""
+
+	return next, nil
+}
+
+// creates the next instance of the task with the given id when it is a recurring one,
+// returns nil when the task does not repeat
+func (board *Board) SpawnNextOccurrence(id int, now time.Time) (*Task, error) {
+	task := board.findTaskById(id)
+
+	if task == nil {
+		return nil, errors.New("Task not found")
+	}
+
+	if !task.IsRecurring() {
+		return nil, nil
+	}
+
+	after := startOfDay(now)
+
+	// tasks completed before their due date repeat after it
+	if due, err := time.ParseInLocation(DueDateLayout, task.DueDate, now.Location()); err == nil && due.After(after) {
+		after = due
+	}
+
+	return board.createNextOccurrence(task, after)
+}
+
+// creates today's instance of every recurring task that was completed or whose due date has passed,
+// returns the created tasks
+func (board *Board) SpawnPastOccurrences(now time.Time) []*Task {
+	today := now.Format(DueDateLayout)
+	yesterday := startOfDay(now).AddDate(0, 0, -1)
+
+	var spawned []*Task
+
+	for _, task := range board.collectTasks(board.root, false, nil) {
+		if !task.IsRecurring() || (!board.IsTaskDone(task) && task.DueDate >= today) {
+			continue
+		}
+
+		if next, err := board.createNextOccurrence(task, yesterday); err == nil {
+			spawned = append(spawned, next)
+		}
+	}
+
+	return spawned
+}
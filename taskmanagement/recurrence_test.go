@@ -0,0 +1,118 @@
+package taskmanagement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	cases := map[string]string{
+		"daily":        RecurrenceDaily,
+		"Weekdays":     RecurrenceWeekdays,
+		"monday":       "weekly:monday",
+		"weekly:fri":   "weekly:friday",
+		"monthly:1":    "monthly:1",
+		"none":         "",
+		"  everyday  ": RecurrenceDaily,
+	}
+
+	for value, expected := range cases {
+		result, err := ParseRecurrence(value)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result != expected {
+			t.Fatalf("Expected: %v, Received: %v", expected, result)
+		}
+	}
+
+	for _, value := range []string{"monthly:32", "yearly", "weekly:xyz"} {
+		if _, err := ParseRecurrence(value); err == nil {
+			t.Fatalf("Error expected for %q but received nil", value)
+		}
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	// 2024-07-12 is a friday
+	friday := time.Date(2024, 7, 12, 18, 30, 0, 0, time.Local)
+
+	cases := []struct {
+		recurrence string
+		after      time.Time
+		expected   string
+	}{
+		{RecurrenceDaily, friday, "2024-07-13"},
+		{RecurrenceWeekdays, friday, "2024-07-15"},
+		{"weekly:friday", friday, "2024-07-19"},
+		{"monthly:1", friday, "2024-08-01"},
+		{"monthly:31", time.Date(2024, 9, 1, 0, 0, 0, 0, time.Local), "2024-09-30"},
+	}
+
+	for _, c := range cases {
+		result, err := nextOccurrence(c.recurrence, c.after)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.Format(DueDateLayout) != c.expected {
+			t.Fatalf("Expected: %v, Received: %v (%v)", c.expected, result.Format(DueDateLayout), c.recurrence)
+		}
+	}
+}
+
+func TestCompletingRecurringTaskSpawnsNextOccurrence(t *testing.T) {
+	now := time.Date(2024, 7, 12, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	task := board.AddTask("standup prep")
+
+	if _, err := board.SetTaskRecurrence(task.Id, "weekdays", now); err != nil {
+		t.Fatal(err)
+	}
+
+	if board.CurrentTask().DueDate != "2024-07-12" {
+		t.Fatalf("Expected: %v, Received: %v", "2024-07-12", board.CurrentTask().DueDate)
+	}
+
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	next, err := board.SpawnNextOccurrence(task.Id, now)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next == nil || next.DueDate != "2024-07-15" || next.Recurrence != RecurrenceWeekdays {
+		t.Fatalf("Expected a weekdays occurrence at %v, Received: %+v", "2024-07-15", next)
+	}
+
+	if board.CurrentTask().IsRecurring() {
+		t.Fatal("Expected the completed instance to stop repeating")
+	}
+
+	if again, _ := board.SpawnNextOccurrence(task.Id, now); again != nil {
+		t.Fatal("Expected no occurrence for a task that does not repeat anymore")
+	}
+}
+
+func TestSpawnPastOccurrences(t *testing.T) {
+	monday := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	task := board.AddTask("inbox zero")
+	board.SetTaskRecurrence(task.Id, "daily", monday.AddDate(0, 0, -3))
+
+	spawned := board.SpawnPastOccurrences(monday)
+
+	if len(spawned) != 1 || spawned[0].DueDate != "2024-07-15" {
+		t.Fatalf("Expected one occurrence at %v, Received: %+v", "2024-07-15", spawned)
+	}
+
+	if len(board.SpawnPastOccurrences(monday)) != 0 {
+		t.Fatal("Expected no occurrences when loading twice in the same day")
+	}
+}
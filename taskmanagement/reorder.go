@@ -0,0 +1,92 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+)
+
+// directions a task can be moved to, inside its list
+const (
+	MoveUp     = "up"
+	MoveDown   = "down"
+	MoveTop    = "top"
+	MoveBottom = "bottom"
+)
+
+func (board *Board) previousVisibleSibling(task *Task) *Task {
+	for current := task.Prev; current != nil; current = current.Prev {
+		if board.isVisible(current) {
+			return current
+		}
+	}
+
+	return nil
+}
+
+func (board *Board) nextVisibleSibling(task *Task) *Task {
+	for current := task.Next; current != nil; current = current.Next {
+		if board.isVisible(current) {
+			return current
+		}
+	}
+
+	return nil
+}
+
+// moves the selected task (with its subtasks) inside its list, hidden tasks are skipped
+// when moving up and down
+func (board *Board) MoveCurrentTask(direction string) error {
+	task := board.task
+
+	if task == nil {
+		return errors.New("You have no selected task")
+	}
+
+	if board.priorityOrder {
+		return errors.New("Tasks are ordered by priority, disable it with :priority-order false to move them manually")
+	}
+
+	if board.sortKey != SortNone {
+		return errors.New(fmt.Sprintf("Tasks are sorted by %v, use :sort none to move them manually", board.sortKey))
+	}
+
+	parent := task.Parent
+
+	var at *Task
+
+	switch direction {
+	case MoveUp:
+		if at = board.previousVisibleSibling(task); at == nil {
+			return errors.New("This task is already the first one")
+		}
+	case MoveDown:
+		next := board.nextVisibleSibling(task)
+
+		if next == nil {
+			return errors.New("This task is already the last one")
+		}
+
+		at = next.Next
+	case MoveTop:
+		if task.Prev == nil {
+			return errors.New("This task is already the first one")
+		}
+
+		at = board.firstChildOf(parent)
+	case MoveBottom:
+		if task.Next == nil {
+			return errors.New("This task is already the last one")
+		}
+
+		at = nil
+	default:
+		return errors.New(fmt.Sprintf(`Invalid direction "%v", expected up, down, top or bottom`, direction))
+	}
+
+	board.saveUndoPoint()
+
+	board.unlinkTask(task)
+	board.insertTask(parent, task, at)
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package taskmanagement
+
+import (
+	"testing"
+)
+
+func TestMoveCurrentTask(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("c")
+	board.AddTask("b")
+	board.AddTask("a")
+
+	if err := board.MoveCurrentTask(MoveUp); err == nil {
+		t.Fatal("Expected an error when moving the first task up")
+	}
+
+	board.MoveCurrentTask(MoveDown)
+
+	expectTaskNames(t, board.Tasks(), "b", "a", "c")
+
+	board.MoveCurrentTask(MoveBottom)
+
+	expectTaskNames(t, board.Tasks(), "b", "c", "a")
+
+	board.MoveCurrentTask(MoveTop)
+
+	expectTaskNames(t, board.Tasks(), "a", "b", "c")
+
+	if board.CurrentTask().Name != "a" {
+		t.Fatalf("Expected: %v, Received: %v", "a", board.CurrentTask().Name)
+	}
+
+	if err := board.MoveCurrentTask("left"); err == nil {
+		t.Fatal("Expected an error for an invalid direction")
+	}
+}
+
+func TestMoveSubtaskStaysInsideParent(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("parent")
+	board.AddSubtask("x")
+	board.SelectParentTask()
+	board.AddSubtask("y")
+
+	board.MoveCurrentTask(MoveUp)
+
+	expectTaskNames(t, board.Tasks(), "parent", "y", "x")
+
+	if err := board.MoveCurrentTask(MoveUp); err == nil {
+		t.Fatal("Expected an error when moving the first subtask up")
+	}
+}
+
+func TestMoveSkipsHiddenTasks(t *testing.T) {
+	board := CreateBoard()
+
+	c := board.AddTask("c")
+	board.AddTask("b")
+	board.AddTask("a")
+
+	d := board.AddTask("d")
+
+	board.AddTaskTag(c.Id, "work")
+	board.AddTaskTag(d.Id, "work")
+	board.SetTagFilter("work")
+	board.SelectTask(d.Id)
+
+	board.MoveCurrentTask(MoveDown)
+
+	expectTaskNames(t, board.Tasks(), "a", "b", "c", "d")
+}
+
+func TestMoveIsRefusedWhileOrderedByPriority(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.AddTask("b")
+	board.SetPriorityOrder(true)
+
+	if err := board.MoveCurrentTask(MoveDown); err == nil {
+		t.Fatal("Expected an error while the board is ordered by priority")
+	}
+}
+
+func TestSaveAndLoadBoardKeepsManualOrder(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	board.AddTask("b")
+	board.AddTask("a")
+	board.MoveCurrentTask(MoveBottom)
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	expectTaskNames(t, loaded.Tasks(), "b", "a")
+}
@@ -4,99 +4,435 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 
 	"github.com/marcos-venicius/daily-term/cycleparser"
 )
 
+// returned when the stored board was changed by another instance since it was loaded
+var ErrBoardChanged = errors.New("The board was changed in another window, use :reload to load the changes")
+
 type Repository struct {
-	file *os.File
+	path         string
+	archivePath  string
+	stamp        fileStamp // database file as this instance last wrote or checked it, see CheckDatabase
+	archiveStamp fileStamp // same for the archive file, see CheckArchive
 }
 
-func (r *Repository) SaveBoard(board *Board) error {
-	if board.root == nil {
-		r.file.Truncate(0)
-		r.file.Seek(0, 0)
+// this is what is stored in the database file
+type databaseData struct {
+	ActiveBoard string      `json:"active_board"`
+	Boards      []boardData `json:"boards"`
+}
 
-		return nil
+type boardData struct {
+	Name          string         `json:"name"`
+	Root          *Task          `json:"root"`
+	PriorityOrder bool           `json:"priority_order"`
+	AutoArchive   int            `json:"auto_archive"`
+	History       []HistoryEntry `json:"history"`
+	Day           string         `json:"day"`
+	Days          []dayData      `json:"days"`
+	SortKey       string         `json:"sort_key"`
+	SortDesc      bool           `json:"sort_desc"`
+	Revision      int            `json:"revision"` // incremented on every save of the board
+}
+
+// decodes the database file, any failure other than a newer schema is an *InvalidDatabaseError
+func decodeDatabase(bytes []byte) (*databaseData, error) {
+	database := &databaseData{}
+	data, err := decodeEnvelope(bytes)
+
+	if _, newer := err.(*SchemaVersionError); newer {
+		return nil, err
 	}
 
-	v, err := cycleparser.ToValue(board.root)
+	if err == nil {
+		err = cycleparser.FromValue(data, database)
+	}
 
 	if err != nil {
-		return err
+		return nil, &InvalidDatabaseError{File: databaseName, Err: err}
 	}
 
-	bytes, err := json.Marshal(v)
+	return database, nil
+}
+
+// fills the names missing in older files and makes sure the active board exists
+func (database *databaseData) normalize() {
+	for index := range database.Boards {
+		if database.Boards[index].Name == "" {
+			database.Boards[index].Name = DefaultBoardName
+		}
+	}
+
+	if database.ActiveBoard == "" {
+		database.ActiveBoard = DefaultBoardName
+
+		if len(database.Boards) > 0 {
+			database.ActiveBoard = database.Boards[0].Name
+		}
+	}
+
+	if database.find(database.ActiveBoard) == -1 {
+		database.Boards = append(database.Boards, boardData{Name: database.ActiveBoard})
+	}
+}
+
+// index of the board with the given name, -1 when it does not exist
+func (database *databaseData) find(name string) int {
+	for index, board := range database.Boards {
+		if board.Name == name {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func (r *Repository) readDatabase() (*databaseData, error) {
+	bytes, err := os.ReadFile(r.path)
+
+	database := &databaseData{}
+
+	if os.IsNotExist(err) || (err == nil && len(bytes) == 0) {
+		database.normalize()
+
+		return database, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	database, err = decodeDatabase(bytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	database.normalize()
+
+	return database, nil
+}
+
+// replaces the database file, keeping the previous version as the newest backup when it is due
+func (r *Repository) writeDatabase(database *databaseData) error {
+	bytes, err := encodeEnvelope(database)
 
 	if err != nil {
 		return err
 	}
 
-	r.file.Truncate(0)
-	r.file.Seek(0, 0)
+	if err = r.rotateBackups(false); err != nil {
+		return errors.New(fmt.Sprintf("Could not keep a backup of the database: %v", err.Error()))
+	}
+
+	if err = writeFileAtomically(r.path, bytes); err != nil {
+		return err
+	}
+
+	r.stamp = readFileStamp(r.path)
 
-	l, err := r.file.Write(bytes)
+	return nil
+}
+
+// how the board is stored, with the revision of its next save
+func (board *Board) data() boardData {
+	return boardData{
+		Name:          board.name,
+		Root:          board.root,
+		PriorityOrder: board.priorityOrder,
+		AutoArchive:   board.autoArchive,
+		History:       board.history,
+		Day:           board.day,
+		Days:          board.days,
+		SortKey:       board.sortKey,
+		SortDesc:      board.sortDesc,
+		Revision:      board.revision + 1,
+	}
+}
+
+// reads the database, applies the change and writes it back while holding the database lock,
+// so changes made by other instances to the rest of the database are kept
+func (r *Repository) updateDatabase(change func(database *databaseData) error) error {
+	unlock, err := r.lock()
 
 	if err != nil {
+		return errors.New(fmt.Sprintf("Could not lock the database: %v", err.Error()))
+	}
+
+	defer unlock()
+
+	database, err := r.readDatabase()
+
+	if err != nil {
+		return err
+	}
+
+	if err = change(database); err != nil {
 		return err
 	}
 
-	if l != len(bytes) {
-		return errors.New("Could not save the current board")
+	return r.writeDatabase(database)
+}
+
+// stores the board in the database, replacing the stored board with the same name.
+// when another instance saved the board after it was loaded, the save is refused with ErrBoardChanged
+func (r *Repository) SaveBoard(board *Board) error {
+	if board.readOnly {
+		return errors.New("Past days are read-only, use :today to go back")
 	}
 
+	stored := board.data()
+
+	err := r.updateDatabase(func(database *databaseData) error {
+		index := database.find(board.name)
+
+		switch {
+		case index != -1 && database.Boards[index].Revision != board.revision:
+			return ErrBoardChanged
+		case index != -1:
+			database.Boards[index] = stored
+		case board.revision != 0:
+			// it was stored before, so another instance renamed or deleted it
+			return ErrBoardChanged
+		default:
+			database.Boards = append(database.Boards, stored)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	board.revision = stored.Revision
+
 	return nil
 }
 
-func (r *Repository) LoadBoard(board *Board) {
-	stat, err := r.file.Stat()
+// loads the stored board with the same name of the given board, a board that was never saved stays empty.
+// a corrupt database is recovered first (see RecoverDatabase), the board is loaded and the *CorruptDatabaseError returned
+func (r *Repository) LoadBoard(board *Board) error {
+	database, err := r.readDatabase()
+
+	var recovered error
+
+	if _, invalid := err.(*InvalidDatabaseError); invalid {
+		recovered = r.RecoverDatabase()
 
-	if stat.Size() == 0 {
-		return
+		// the recovery error tells why the database is still unreadable
+		if _, ok := recovered.(*CorruptDatabaseError); !ok && recovered != nil {
+			return recovered
+		}
+
+		database, err = r.readDatabase()
 	}
 
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	var bytes []byte = make([]byte, stat.Size())
+	index := database.find(board.name)
+
+	if index == -1 {
+		return recovered
+	}
 
-	readSize, err := r.file.Read(bytes)
+	stored := database.Boards[index]
+
+	board.priorityOrder = stored.PriorityOrder
+	board.autoArchive = stored.AutoArchive
+	board.history = stored.History
+	board.day = stored.Day
+	board.days = stored.Days
+	board.sortKey = stored.SortKey
+	board.sortDesc = stored.SortDesc
+	board.revision = stored.Revision
+
+	// ids of deleted tasks are still referenced by the history, the dependencies and the past days,
+	// so they are never given to new tasks
+	for _, entry := range board.history {
+		board.idCluster.MarkAsUsed(entry.TaskId)
+	}
+
+	for _, day := range board.days {
+		board.reserveSubtreeIds(day.Root)
+	}
+
+	if stored.Root == nil {
+		return recovered
+	}
+
+	// old versions could store a task that is not the first one as root
+	for stored.Root.Prev != nil {
+		stored.Root = stored.Root.Prev
+	}
+
+	board.root = stored.Root
+	board.task = board.root
+	board.reserveSubtreeIds(board.root)
+
+	return recovered
+}
+
+// marks as used the ids of the given tasks (with their siblings and subtasks) and the ids they depend on
+func (board *Board) reserveSubtreeIds(first *Task) {
+	for _, task := range board.collectTasks(first, false, nil) {
+		board.idCluster.MarkAsUsed(task.Id)
+
+		for _, id := range task.BlockedBy {
+			board.idCluster.MarkAsUsed(id)
+		}
+	}
+}
+
+// reads the task states from the states file, creating it with the default states when it does not exist.
+// when the file is invalid, the default workflow is returned along with the error
+func (r *Repository) LoadWorkflow() (*Workflow, error) {
+	path := createPath(statesFileName)
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		workflow := DefaultWorkflow()
+
+		return workflow, writeWorkflowFile(path, workflow)
+	}
+
+	workflow, err := loadWorkflowFile(path)
+
+	if err != nil {
+		return DefaultWorkflow(), errors.New(fmt.Sprintf("Could not load %v: %v", statesFileName, err.Error()))
+	}
+
+	return workflow, nil
+}
+
+// stores the archive merged with the changes other instances made to the archive file (see Archive.merge)
+func (r *Repository) SaveArchive(archive *Archive) error {
+	unlock, err := r.lock()
+
+	if err != nil {
+		return errors.New(fmt.Sprintf("Could not lock the database: %v", err.Error()))
+	}
+
+	defer unlock()
+
+	entries := archive.entries
+
+	// a deleted archive file is written again from memory
+	if stored, exists, err := r.readArchive(); err != nil {
+		return err
+	} else if exists {
+		entries = archive.merge(stored)
+	}
+
+	v, err := cycleparser.ToValue(&archiveData{
+		Entries: entries,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(v)
+
+	if err != nil {
+		return err
+	}
+
+	if err = writeFileAtomically(r.archivePath, bytes); err != nil {
+		return err
+	}
+
+	r.archiveStamp = readFileStamp(r.archivePath)
+	archive.markSynced(entries)
+
+	return nil
+}
+
+// the stored archive entries, tells if the archive file exists.
+// a file that cannot be decoded is an *InvalidDatabaseError
+func (r *Repository) readArchive() ([]ArchivedTask, bool, error) {
+	bytes, err := os.ReadFile(r.archivePath)
+
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
 
 	if err != nil {
-		panic(err)
+		return nil, false, err
 	}
 
-	if readSize != int(stat.Size()) {
-		panic(fmt.Sprintf("Size file length %d, but read %d", stat.Size(), readSize))
+	if len(bytes) == 0 {
+		return nil, true, nil
 	}
 
+	entries, err := decodeArchive(bytes)
+
+	return entries, true, err
+}
+
+func decodeArchive(bytes []byte) ([]ArchivedTask, error) {
 	data := &cycleparser.Value{}
+	stored := &archiveData{}
 
-	err = json.Unmarshal(bytes, data)
+	err := json.Unmarshal(bytes, data)
+
+	if err == nil {
+		err = cycleparser.FromValue(data, stored)
+	}
 
 	if err != nil {
-		log.Fatal(err)
+		return nil, &InvalidDatabaseError{File: archiveName, Err: err}
 	}
 
-	root := &Task{}
+	var entries []ArchivedTask
+
+	for _, entry := range stored.Entries {
+		if entry.Task != nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// reads the archived tasks, a missing archive file is an empty archive.
+// a corrupt archive file is moved aside (archive.json.corrupt-<timestamp>) and an empty archive is returned with a *CorruptDatabaseError
+func (r *Repository) LoadArchive() (*Archive, error) {
+	archive := CreateArchive()
 
-	err = cycleparser.FromValue(data, root)
+	unlock, err := r.lock()
 
 	if err != nil {
-		panic(err)
+		return archive, errors.New(fmt.Sprintf("Could not lock the database: %v", err.Error()))
 	}
 
-	board.root = root
-	board.task = board.root
+	defer unlock()
+
+	r.archiveStamp = readFileStamp(r.archivePath)
+
+	entries, _, err := r.readArchive()
 
-	current := board.root
+	if invalid, ok := err.(*InvalidDatabaseError); ok {
+		corrupt, err := moveCorruptFile(r.archivePath, invalid)
 
-	for current != nil {
-		board.idCluster.MarkAsUsed(current.Id)
+		if err != nil {
+			return archive, err
+		}
 
-		current = current.Next
+		r.archiveStamp = readFileStamp(r.archivePath)
+
+		return archive, corrupt
 	}
+
+	if err != nil {
+		return archive, err
+	}
+
+	archive.markSynced(entries)
+
+	return archive, nil
 }
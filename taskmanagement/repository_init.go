@@ -1,42 +1,42 @@
 package taskmanagement
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"os"
 )
 
-// TODO: watch if database is deleted or modified
-
 const (
-	appFolderName = ".daily-term"
-	databaseName  = "database.json"
+	appFolderName  = ".daily-term"
+	databaseName   = "database.json"
+	statesFileName = "states.json"
+	archiveName    = "archive.json"
 )
 
-func ensureAppFolderExists() {
+func ensureAppFolderExists() error {
 	path := createPath()
 
 	stat, err := os.Stat(path)
 
-	if err == nil {
-		return
-	}
-
 	if os.IsNotExist(err) {
-		if err = os.Mkdir(path, 0777); err != nil {
-			panic(err)
-		}
+		return os.Mkdir(path, 0777)
+	}
 
-		return
+	if err != nil {
+		return err
 	}
 
 	if !stat.IsDir() {
-		panic(fmt.Sprintf(`"%v" is not a directory`, appFolderName))
+		return errors.New(fmt.Sprintf(`"%v" is not a directory`, appFolderName))
 	}
+
+	return nil
 }
 
 func CreateRepository() (*Repository, error) {
-	ensureAppFolderExists()
+	if err := ensureAppFolderExists(); err != nil {
+		return nil, err
+	}
 
 	dbPath := createPath(databaseName)
 
@@ -46,15 +46,15 @@ func CreateRepository() (*Repository, error) {
 		return nil, err
 	}
 
+	file.Close()
+
 	return &Repository{
-		file: file,
+		path:        dbPath,
+		archivePath: createPath(archiveName),
+		stamp:       readFileStamp(dbPath),
 	}, nil
 }
 
+// saves replace the database file instead of writing into it, so there is no open file to release
 func (r *Repository) CloseRepository() {
-	err := r.file.Close()
-
-	if err != nil {
-		log.Fatal(err)
-	}
 }
@@ -0,0 +1,281 @@
+package taskmanagement
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcos-venicius/daily-term/cycleparser"
+)
+
+func createTestRepository(t *testing.T) *Repository {
+	folder := t.TempDir()
+
+	return &Repository{
+		path:        filepath.Join(folder, databaseName),
+		archivePath: filepath.Join(folder, archiveName),
+	}
+}
+
+func TestSaveAndLoadBoardKeepsDueDate(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	task := board.AddTask("deploy")
+
+	if _, err := board.SetTaskDueDate(task.Id, "2024-07-15"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	tasks := loaded.Tasks()
+
+	if len(tasks) != 1 {
+		t.Fatalf("Expected: %d, Received: %d", 1, len(tasks))
+	}
+
+	if tasks[0].DueDate != "2024-07-15" {
+		t.Fatalf("Expected: %v, Received: %v", "2024-07-15", tasks[0].DueDate)
+	}
+}
+
+func TestSaveAndLoadBoardKeepsPrioritiesAndTags(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	task := board.AddTask("deploy")
+	board.AddTask("review")
+
+	board.SetTaskPriority(task.Id, PriorityUrgent)
+	board.SetPriorityOrder(true)
+	board.AddTaskTag(task.Id, "work")
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	if !loaded.PriorityOrder() {
+		t.Fatal("Expected priority order to be enabled")
+	}
+
+	expectTaskNames(t, loaded.Tasks(), "deploy", "review")
+
+	if loaded.Tasks()[0].Priority != PriorityUrgent {
+		t.Fatalf("Expected: %v, Received: %v", PriorityUrgent, loaded.Tasks()[0].Priority)
+	}
+
+	if !loaded.Tasks()[0].HasTag("work") {
+		t.Fatalf("Expected: %v, Received: %v", []string{"work"}, loaded.Tasks()[0].Tags)
+	}
+}
+
+func TestLoadLegacyBoard(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	board.AddTask("a")
+	board.AddTask("b")
+
+	v, err := cycleparser.ToValue(board.root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bytes, err := json.Marshal(v)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(repository.path, bytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	expectTaskNames(t, loaded.Tasks(), "b", "a")
+}
+
+func TestSaveAndLoadBoardKeepsSubtasks(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	board.AddTask("b")
+	board.AddTask("a")
+	board.AddSubtask("a.1")
+	board.AddSubtask("a.1.1")
+	board.SelectParentTask()
+	board.SelectParentTask()
+	board.AddSubtask("a.2")
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	expectTaskNames(t, loaded.Tasks(), "a", "a.1", "a.1.1", "a.2", "b")
+
+	for _, task := range loaded.collectTasks(loaded.root, false, nil) {
+		for _, subtask := range task.Subtasks() {
+			if subtask.Parent != task {
+				t.Fatalf("Expected %v to be the parent of %v", task.Name, subtask.Name)
+			}
+		}
+	}
+}
+
+func TestSaveAndLoadBoardKeepsTimeEntries(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	board.AddTask("a")
+	board.MoveCurrentSelectedTaskTo(inProgress)
+	board.MoveCurrentSelectedTaskTo(completed)
+	board.MoveCurrentSelectedTaskTo(inProgress)
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	task := loaded.CurrentTask()
+
+	if len(task.TimeEntries) != 2 {
+		t.Fatalf("Expected: %d, Received: %d", 2, len(task.TimeEntries))
+	}
+
+	if task.TimeEntries[0].Stop == 0 || !task.IsTracking() {
+		t.Fatalf("Expected a stopped and a running entry, Received: %+v", task.TimeEntries)
+	}
+}
+
+func TestLoadBoardReservesIdsOfDeletedTasks(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	board.AddTask("deleted")
+	board.AddTask("kept")
+
+	// the ids are fixed so every id but one can be reserved
+	kept := board.root
+	deleted := kept.Next
+	kept.Id = 0
+	deleted.Id = 1
+	kept.BlockedBy = []int{1, 2}
+
+	if err := board.DeleteTaskById(1); err != nil {
+		t.Fatal(err)
+	}
+
+	board.days = []dayData{{Date: "2024-07-14", Root: &Task{Id: 3, Name: "yesterday"}}}
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+	loaded.idCluster.SetCustomIdMaxSize(5)
+
+	if id := loaded.idCluster.NewId(); id != 4 {
+		t.Fatalf("Expected: %d, Received: %d", 4, id)
+	}
+}
+
+func TestSaveBoardRefusesBoardChangedByAnotherInstance(t *testing.T) {
+	repository := createTestRepository(t)
+
+	first := CreateBoard()
+	second := CreateBoard()
+
+	repository.LoadBoard(first)
+	repository.LoadBoard(second)
+
+	first.AddTask("a")
+
+	if err := repository.SaveBoard(first); err != nil {
+		t.Fatal(err)
+	}
+
+	second.AddTask("b")
+
+	if err := repository.SaveBoard(second); err != ErrBoardChanged {
+		t.Fatalf("Expected: %v, Received: %v", ErrBoardChanged, err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+	expectTaskNames(t, loaded.Tasks(), "a")
+
+	// after loading the changes, the board can be saved again
+	repository.LoadBoard(second)
+	second.AddTask("b")
+
+	if err := repository.SaveBoard(second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveBoardKeepsBoardsSavedByAnotherInstance(t *testing.T) {
+	repository := createTestRepository(t)
+
+	if err := repository.AddBoard("work"); err != nil {
+		t.Fatal(err)
+	}
+
+	home := CreateBoard()
+	work := CreateNamedBoard("work")
+
+	repository.LoadBoard(home)
+	repository.LoadBoard(work)
+
+	work.AddTask("deploy")
+
+	if err := repository.SaveBoard(work); err != nil {
+		t.Fatal(err)
+	}
+
+	home.AddTask("groceries")
+
+	if err := repository.SaveBoard(home); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateNamedBoard("work")
+	repository.LoadBoard(loaded)
+	expectTaskNames(t, loaded.Tasks(), "deploy")
+}
+
+func TestSaveBoardRefusesBoardDeletedByAnotherInstance(t *testing.T) {
+	repository := createTestRepository(t)
+
+	work := CreateNamedBoard("work")
+
+	if err := repository.SaveBoard(work); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.DeleteBoard("work"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.SaveBoard(work); err != ErrBoardChanged {
+		t.Fatalf("Expected: %v, Received: %v", ErrBoardChanged, err)
+	}
+}
@@ -0,0 +1,155 @@
+package taskmanagement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/marcos-venicius/daily-term/cycleparser"
+)
+
+// version of the database format written by this version of the application.
+// when the format changes, bump it and append the migration from the previous version
+const schemaVersion = 2
+
+// upgrades the serialized database from one version to the next, before it is deserialized
+type migration func(data *cycleparser.Value) (*cycleparser.Value, error)
+
+// migrations[n] upgrades version n to version n + 1
+var migrations = []migration{
+	migrateRootTask,
+	migrateSingleBoard,
+}
+
+// what is stored in the database file
+type databaseEnvelope struct {
+	Version int                `json:"version"`
+	Data    *cycleparser.Value `json:"data"`
+}
+
+func encodeEnvelope(database *databaseData) ([]byte, error) {
+	v, err := cycleparser.ToValue(database)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&databaseEnvelope{Version: schemaVersion, Data: v})
+}
+
+// reads the serialized database of the file, upgraded to the current version
+func decodeEnvelope(bytes []byte) (*cycleparser.Value, error) {
+	// the data is read after the version is checked, newer versions may store values this version cannot read
+	envelope := &struct {
+		Version int             `json:"version"`
+		Data    json.RawMessage `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(bytes, envelope); err != nil {
+		return nil, err
+	}
+
+	data := &cycleparser.Value{}
+
+	if envelope.Data == nil {
+		// files written before the envelope existed
+		if err := json.Unmarshal(bytes, data); err != nil {
+			return nil, err
+		}
+
+		return migrate(data, unversionedSchema(data))
+	}
+
+	if envelope.Version > schemaVersion {
+		return nil, &SchemaVersionError{Version: envelope.Version}
+	}
+
+	if err := json.Unmarshal(envelope.Data, data); err != nil {
+		return nil, err
+	}
+
+	return migrate(data, envelope.Version)
+}
+
+func migrate(data *cycleparser.Value, version int) (*cycleparser.Value, error) {
+	if version > schemaVersion {
+		return nil, &SchemaVersionError{Version: version}
+	}
+
+	if version < 0 {
+		return nil, errors.New(fmt.Sprintf("Unknown database schema %d", version))
+	}
+
+	var err error
+
+	for ; version < schemaVersion; version++ {
+		data, err = migrations[version](data)
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Could not upgrade the database from schema %d: %v", version, err.Error()))
+		}
+	}
+
+	return data, nil
+}
+
+// the version of a file without envelope, told by its fields
+func unversionedSchema(data *cycleparser.Value) int {
+	switch {
+	case structFields(data) == nil:
+		return 0
+	case structFields(data)["boards"] != nil:
+		return 2
+	case structFields(data)["root"] != nil:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// fields of a serialized pointer to a struct, nil when it is something else
+func structFields(data *cycleparser.Value) map[string]any {
+	if data == nil || data.Kind != cycleparser.Ptr {
+		return nil
+	}
+
+	inner, ok := data.Value.(*cycleparser.Value)
+
+	if !ok || inner.Kind != cycleparser.Struct {
+		return nil
+	}
+
+	fields, _ := inner.Value.(map[string]any)
+
+	return fields
+}
+
+// the values created by migrations are never referenced, so they do not need a reference id
+func pointerTo(fields map[string]any) *cycleparser.Value {
+	return &cycleparser.Value{
+		Kind:  cycleparser.Ptr,
+		Value: &cycleparser.Value{Kind: cycleparser.Struct, Value: fields},
+	}
+}
+
+// version 0 stored the root task directly, version 1 stores a board with its root task
+func migrateRootTask(data *cycleparser.Value) (*cycleparser.Value, error) {
+	if structFields(data) == nil {
+		return nil, errors.New("The root task is not a struct")
+	}
+
+	return pointerTo(map[string]any{"root": data}), nil
+}
+
+// version 1 stored a single board, version 2 stores a list of boards
+func migrateSingleBoard(data *cycleparser.Value) (*cycleparser.Value, error) {
+	if structFields(data) == nil {
+		return nil, errors.New("The board is not a struct")
+	}
+
+	board := data.Value.(*cycleparser.Value)
+
+	return pointerTo(map[string]any{
+		"boards": &cycleparser.Value{Kind: cycleparser.Slice, Value: []any{board}},
+	}), nil
+}
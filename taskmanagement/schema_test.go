@@ -0,0 +1,109 @@
+package taskmanagement
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestMigrationsReachSchemaVersion(t *testing.T) {
+	if len(migrations) != schemaVersion {
+		t.Fatalf("Expected: %d, Received: %d", schemaVersion, len(migrations))
+	}
+}
+
+func TestSaveBoardWritesSchemaVersion(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+
+	bytes, err := os.ReadFile(repository.path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := &databaseEnvelope{}
+
+	if err = json.Unmarshal(bytes, envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	if envelope.Version != schemaVersion {
+		t.Fatalf("Expected: %d, Received: %d", schemaVersion, envelope.Version)
+	}
+
+	if envelope.Data == nil {
+		t.Fatalf("Expected: %v, Received: %v", "data", envelope.Data)
+	}
+}
+
+func TestLoadBoardUpgradesUnversionedDatabase(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+
+	bytes, err := os.ReadFile(repository.path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := &databaseEnvelope{}
+
+	if err = json.Unmarshal(bytes, envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	// files written before the envelope existed stored the data alone
+	bytes, err = json.Marshal(envelope.Data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(repository.path, bytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+	expectTaskNames(t, loaded.Tasks(), "a")
+}
+
+func TestLoadNewerSchemaFails(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+
+	bytes, err := os.ReadFile(repository.path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := &databaseEnvelope{}
+
+	if err = json.Unmarshal(bytes, envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	envelope.Version = schemaVersion + 1
+
+	if bytes, err = json.Marshal(envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(repository.path, bytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = repository.BoardNames(); err == nil {
+		t.Fatalf("Expected: %v, Received: %v", "error", err)
+	}
+
+	// the newer file must not be overwritten
+	if err = repository.AddBoard("work"); err == nil {
+		t.Fatalf("Expected: %v, Received: %v", "error", err)
+	}
+}
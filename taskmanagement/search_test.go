@@ -0,0 +1,58 @@
+package taskmanagement
+
+import (
+	"testing"
+)
+
+func TestSearchVisibleTasks(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("Deploy api")
+	board.AddSubtask("check deploy logs")
+	board.AddTask("write docs")
+
+	matches := board.SearchVisibleTasks("DEPLOY")
+
+	if len(matches) != 2 || matches[0].Name != "Deploy api" || matches[1].Name != "check deploy logs" {
+		t.Fatalf("Expected: %v, Received: %v", []string{"Deploy api", "check deploy logs"}, matches)
+	}
+
+	board.SelectNextTask()
+	board.FoldCurrentTask()
+
+	if matches := board.SearchVisibleTasks("deploy"); len(matches) != 1 {
+		t.Fatalf("Expected: %d, Received: %d", 1, len(matches))
+	}
+
+	if matches := board.SearchVisibleTasks(""); len(matches) != 0 {
+		t.Fatalf("Expected: %d, Received: %d", 0, len(matches))
+	}
+}
+
+func TestSelectTask(t *testing.T) {
+	board := CreateBoard()
+
+	parent := board.AddTask("parent")
+	child, _ := board.AddSubtask("child")
+	other := board.AddTask("other")
+
+	if err := board.SelectTask(parent.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	board.FoldCurrentTask()
+
+	if err := board.SelectTask(child.Id); err == nil {
+		t.Fatal("Expected an error when selecting a hidden task")
+	}
+
+	if board.CurrentTask().Id != parent.Id {
+		t.Fatalf("Expected: %v, Received: %v", parent.Id, board.CurrentTask().Id)
+	}
+
+	board.SelectTask(other.Id)
+
+	if board.CurrentTask().Id != other.Id {
+		t.Fatalf("Expected: %v, Received: %v", other.Id, board.CurrentTask().Id)
+	}
+}
@@ -0,0 +1,108 @@
+package taskmanagement
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// these are all keys the visible tasks can be sorted by
+const (
+	SortNone    = ""
+	SortState   = "state"
+	SortId      = "id"
+	SortName    = "name"
+	SortCreated = "created"
+)
+
+func (board *Board) SortKey() string {
+	return board.sortKey
+}
+
+func (board *Board) SortDescending() bool {
+	return board.sortDesc
+}
+
+// sorts the visible tasks (each list of subtasks separately) without changing the board order,
+// SortNone goes back to the board order
+func (board *Board) SetSort(key string, descending bool) error {
+	switch key {
+	case SortNone, SortState, SortId, SortName, SortCreated:
+		break
+	default:
+		return errors.New(fmt.Sprintf(`Invalid sort "%v", expected state, id, name, created or none`, key))
+	}
+
+	board.sortKey = key
+	board.sortDesc = descending && key != SortNone
+
+	board.ensureVisibleSelection()
+
+	return nil
+}
+
+// tasks being worked on come first, then the other unfinished ones and then the finished ones.
+// states in the same group follow the workflow order
+func (board *Board) stateRank(task *Task) (int, int) {
+	group := 1
+
+	if board.workflow.IsDone(task.State) {
+		group = 2
+	} else if board.workflow.IsTracked(task.State) {
+		group = 0
+	}
+
+	for index, state := range board.workflow.States() {
+		if state.Id == task.State {
+			return group, index
+		}
+	}
+
+	return group, len(board.workflow.States())
+}
+
+func (board *Board) compareTasks(a, b *Task) int {
+	switch board.sortKey {
+	case SortState:
+		aGroup, aIndex := board.stateRank(a)
+		bGroup, bIndex := board.stateRank(b)
+
+		if aGroup != bGroup {
+			return aGroup - bGroup
+		}
+
+		return aIndex - bIndex
+	case SortId:
+		return a.Id - b.Id
+	case SortName:
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	case SortCreated:
+		switch {
+		case a.CreatedAt < b.CreatedAt:
+			return -1
+		case a.CreatedAt > b.CreatedAt:
+			return 1
+		}
+
+		// the creation time has only seconds, tasks created in the same second are ordered by id
+		return a.Id - b.Id
+	}
+
+	return 0
+}
+
+// tasks that compare as equal keep the board order
+func (board *Board) sortSiblings(tasks []*Task) {
+	if board.sortKey == SortNone {
+		return
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if board.sortDesc {
+			return board.compareTasks(tasks[j], tasks[i]) < 0
+		}
+
+		return board.compareTasks(tasks[i], tasks[j]) < 0
+	})
+}
@@ -0,0 +1,109 @@
+package taskmanagement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortByStateKeepsBoardOrder(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("done")
+	board.MoveCurrentSelectedTaskTo(completed)
+	board.AddTask("todo")
+	board.AddTask("doing")
+	board.MoveCurrentSelectedTaskTo(inProgress)
+
+	board.AddTask("first")
+
+	if err := board.SetSort(SortState, false); err != nil {
+		t.Fatal(err)
+	}
+
+	expectTaskNames(t, board.VisibleTasks(), "doing", "first", "todo", "done")
+
+	// the stored order does not change
+	expectTaskNames(t, board.Tasks(), "first", "doing", "todo", "done")
+
+	board.SetSort(SortState, true)
+
+	expectTaskNames(t, board.VisibleTasks(), "done", "first", "todo", "doing")
+}
+
+func TestSortByNameAndCreation(t *testing.T) {
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.clock = func() time.Time { return now }
+
+	board.AddTask("Banana")
+	now = now.Add(time.Minute)
+	board.AddTask("apple")
+	now = now.Add(time.Minute)
+	board.AddTask("cherry")
+
+	board.SetSort(SortName, false)
+
+	expectTaskNames(t, board.VisibleTasks(), "apple", "Banana", "cherry")
+
+	board.SetSort(SortCreated, false)
+
+	expectTaskNames(t, board.VisibleTasks(), "Banana", "apple", "cherry")
+
+	// the selection follows the sorted tasks
+	board.SelectPreviousTask()
+
+	if board.CurrentTask().Name != "apple" {
+		t.Fatalf("Expected: %v, Received: %v", "apple", board.CurrentTask().Name)
+	}
+
+	board.SetSort(SortNone, false)
+
+	expectTaskNames(t, board.VisibleTasks(), "cherry", "apple", "Banana")
+
+	if err := board.SetSort("size", false); err == nil {
+		t.Fatal("Expected an error for an invalid sort key")
+	}
+}
+
+func TestSortByCreationBreaksTiesById(t *testing.T) {
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.clock = func() time.Time { return now }
+
+	board.AddTask("second")
+	board.AddTask("first")
+	board.AddTask("third")
+
+	// the board order is third, first, second
+	board.root.Id = 3
+	board.root.Next.Id = 1
+	board.root.Next.Next.Id = 2
+
+	board.SetSort(SortCreated, false)
+
+	expectTaskNames(t, board.VisibleTasks(), "first", "second", "third")
+
+	board.SetSort(SortCreated, true)
+
+	expectTaskNames(t, board.VisibleTasks(), "third", "second", "first")
+}
+
+func TestSaveAndLoadBoardKeepsSort(t *testing.T) {
+	repository := createTestRepository(t)
+
+	board := CreateBoard()
+	board.SetSort(SortId, true)
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+
+	if loaded.SortKey() != SortId || !loaded.SortDescending() {
+		t.Fatalf("Expected: %v desc, Received: %v %v", SortId, loaded.SortKey(), loaded.SortDescending())
+	}
+}
@@ -1,14 +1,91 @@
 package taskmanagement
 
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
 func (task *Task) Symbol(currentSelectedTaskId int) rune {
 	if task.Id == currentSelectedTaskId {
-		switch task.State {
-		case Todo, InProgress, Completed:
-			return '*'
-		default:
-			return '*'
-		}
+		return '*'
 	}
 
 	return ' '
 }
+
+func (task *Task) HasDueDate() bool {
+	return task.DueDate != ""
+}
+
+// a task is overdue when its due date is before the day of "now" (finished tasks are never overdue, see Board.IsTaskDone)
+func (task *Task) IsOverdue(now time.Time) bool {
+	if !task.HasDueDate() {
+		return false
+	}
+
+	return task.DueDate < now.Format(DueDateLayout)
+}
+
+func (task *Task) IsDueToday(now time.Time) bool {
+	if !task.HasDueDate() {
+		return false
+	}
+
+	return task.DueDate == now.Format(DueDateLayout)
+}
+
+func (task *Task) HasTag(tag string) bool {
+	for _, t := range task.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tells if the name has the given text, ignoring case
+func (task *Task) NameContains(text string) bool {
+	return strings.Contains(strings.ToLower(task.Name), strings.ToLower(text))
+}
+
+// tags are case insensitive single words, they may be typed with a leading "#"
+func normalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+
+	if tag == "" {
+		return "", errors.New("Tag cannot be empty")
+	}
+
+	if strings.ContainsAny(tag, " \t") {
+		return "", errors.New("Tag cannot contain spaces")
+	}
+
+	return tag, nil
+}
+
+func (task *Task) HasSubtasks() bool {
+	return task.Children != nil
+}
+
+// how many parents the task has, top level tasks have depth 0
+func (task *Task) Depth() int {
+	depth := 0
+
+	for parent := task.Parent; parent != nil; parent = parent.Parent {
+		depth++
+	}
+
+	return depth
+}
+
+func (task *Task) Subtasks() []*Task {
+	var subtasks []*Task
+
+	for current := task.Children; current != nil; current = current.Next {
+		subtasks = append(subtasks, current)
+	}
+
+	return subtasks
+}
@@ -0,0 +1,60 @@
+package taskmanagement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskDueDateStatus(t *testing.T) {
+	now := time.Date(2024, 7, 15, 10, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		dueDate  string
+		overdue  bool
+		dueToday bool
+	}{
+		{"", false, false},
+		{"2024-07-14", true, false},
+		{"2024-07-15", false, true},
+		{"2024-07-16", false, false},
+	}
+
+	for _, c := range cases {
+		task := Task{DueDate: c.dueDate}
+
+		if task.IsOverdue(now) != c.overdue {
+			t.Fatalf("Expected: %v, Received: %v (%v)", c.overdue, task.IsOverdue(now), c.dueDate)
+		}
+
+		if task.IsDueToday(now) != c.dueToday {
+			t.Fatalf("Expected: %v, Received: %v (%v)", c.dueToday, task.IsDueToday(now), c.dueDate)
+		}
+	}
+}
+
+func TestParseDueDate(t *testing.T) {
+	now := time.Date(2024, 7, 15, 10, 0, 0, 0, time.Local)
+
+	cases := map[string]string{
+		"2024-08-01": "2024-08-01",
+		"today":      "2024-07-15",
+		"tomorrow":   "2024-07-16",
+		"none":       "",
+	}
+
+	for value, expected := range cases {
+		result, err := parseDueDate(value, now)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result != expected {
+			t.Fatalf("Expected: %v, Received: %v", expected, result)
+		}
+	}
+
+	if _, err := parseDueDate("15/07/2024", now); err == nil {
+		t.Fatal("Error expected but received nil")
+	}
+}
@@ -0,0 +1,95 @@
+package taskmanagement
+
+import (
+	"fmt"
+	"time"
+)
+
+// a period of time in which a task was in progress
+type TimeEntry struct {
+	Start int64 `json:"start"` // unix timestamp
+	Stop  int64 `json:"stop"`  // unix timestamp, 0 while the task is still in progress
+}
+
+// tells if the task has a running clock
+func (task *Task) IsTracking() bool {
+	return len(task.TimeEntries) > 0 && task.TimeEntries[len(task.TimeEntries)-1].Stop == 0
+}
+
+func (task *Task) startTracking(now time.Time) {
+	if task.IsTracking() {
+		return
+	}
+
+	task.TimeEntries = append(task.TimeEntries, TimeEntry{Start: now.Unix()})
+}
+
+func (task *Task) stopTracking(now time.Time) {
+	if !task.IsTracking() {
+		return
+	}
+
+	task.TimeEntries[len(task.TimeEntries)-1].Stop = now.Unix()
+}
+
+// accumulated time the task was in progress, including the running clock
+func (task *Task) TimeSpent(now time.Time) time.Duration {
+	var total int64
+
+	for _, entry := range task.TimeEntries {
+		stop := entry.Stop
+
+		if stop == 0 {
+			stop = now.Unix()
+		}
+
+		if stop > entry.Start {
+			total += stop - entry.Start
+		}
+	}
+
+	return time.Duration(total) * time.Second
+}
+
+// formats a duration like 1h25m, with seconds it is formatted like 1h25m07s
+func FormatDuration(duration time.Duration, withSeconds bool) string {
+	duration = duration.Truncate(time.Second)
+
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes()) % 60
+	seconds := int(duration.Seconds()) % 60
+
+	text := fmt.Sprintf("%dm", minutes)
+
+	if hours > 0 {
+		text = fmt.Sprintf("%dh%02dm", hours, minutes)
+	}
+
+	if withSeconds {
+		text = fmt.Sprintf("%v%02ds", text, seconds)
+	}
+
+	return text
+}
+
+// changes the task state, starting the clock when it goes to a tracked state and stopping it when it leaves.
+// it also keeps track of when the task was finished
+func (board *Board) setTaskState(task *Task, state TaskState) {
+	now := board.clock()
+
+	if board.workflow.IsTracked(state) {
+		task.startTracking(now)
+	} else {
+		task.stopTracking(now)
+	}
+
+	if !board.workflow.IsDone(state) {
+		task.CompletedAt = 0
+	} else if !board.workflow.IsDone(task.State) {
+		task.CompletedAt = now.Unix()
+	}
+
+	board.record(task, HistoryState, board.workflow.Name(task.State), board.workflow.Name(state))
+
+	task.State = state
+}
@@ -0,0 +1,68 @@
+package taskmanagement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStateTransitionsTrackTimeInProgress(t *testing.T) {
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.Local)
+
+	board := CreateBoard()
+	board.clock = func() time.Time { return now }
+
+	board.AddTask("a")
+
+	board.MoveCurrentSelectedTaskTo(inProgress)
+
+	now = now.Add(25 * time.Minute)
+
+	if !board.CurrentTask().IsTracking() {
+		t.Fatal("Expected the task clock to be running")
+	}
+
+	board.MoveCurrentSelectedTaskTo(todo)
+
+	now = now.Add(time.Hour)
+
+	board.MoveCurrentSelectedTaskTo(inProgress)
+
+	now = now.Add(time.Hour)
+
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	now = now.Add(time.Hour)
+
+	task := board.CurrentTask()
+
+	if task.IsTracking() {
+		t.Fatal("Expected the task clock to be stopped")
+	}
+
+	if len(task.TimeEntries) != 2 {
+		t.Fatalf("Expected: %d, Received: %d", 2, len(task.TimeEntries))
+	}
+
+	if spent := task.TimeSpent(now); spent != 85*time.Minute {
+		t.Fatalf("Expected: %v, Received: %v", 85*time.Minute, spent)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := []struct {
+		duration    time.Duration
+		withSeconds bool
+		expected    string
+	}{
+		{85 * time.Minute, false, "1h25m"},
+		{85*time.Minute + 7*time.Second, true, "1h25m07s"},
+		{42 * time.Second, false, "0m"},
+		{3 * time.Minute, true, "3m00s"},
+	}
+
+	for _, c := range cases {
+		if result := FormatDuration(c.duration, c.withSeconds); result != c.expected {
+			t.Fatalf("Expected: %v, Received: %v", c.expected, result)
+		}
+	}
+}
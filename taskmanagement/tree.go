@@ -0,0 +1,209 @@
+package taskmanagement
+
+import (
+	"errors"
+)
+
+// first task of the list that holds the subtasks of parent (the board root when parent is nil)
+func (board *Board) firstChildOf(parent *Task) *Task {
+	if parent == nil {
+		return board.root
+	}
+
+	return parent.Children
+}
+
+func (board *Board) setFirstChildOf(parent, task *Task) {
+	if parent == nil {
+		board.root = task
+	} else {
+		parent.Children = task
+	}
+}
+
+// removes the task (with its subtasks) from its list without touching the selection
+func (board *Board) unlinkTask(task *Task) {
+	if task.Prev != nil {
+		task.Prev.Next = task.Next
+	} else if board.firstChildOf(task.Parent) == task {
+		board.setFirstChildOf(task.Parent, task.Next)
+	}
+
+	if task.Next != nil {
+		task.Next.Prev = task.Prev
+	}
+
+	task.Prev = nil
+	task.Next = nil
+	task.Parent = nil
+}
+
+// inserts the task before "at", in the same list "at" belongs to
+func (board *Board) insertTaskBefore(task, at *Task) {
+	task.Parent = at.Parent
+	task.Prev = at.Prev
+	task.Next = at
+
+	if at.Prev != nil {
+		at.Prev.Next = task
+	} else {
+		board.setFirstChildOf(at.Parent, task)
+	}
+
+	at.Prev = task
+}
+
+// appends the task at the end of the subtasks of parent (or of the board when parent is nil)
+func (board *Board) appendTask(parent, task *Task) {
+	task.Parent = parent
+	task.Prev = nil
+	task.Next = nil
+
+	last := board.firstChildOf(parent)
+
+	if last == nil {
+		board.setFirstChildOf(parent, task)
+		return
+	}
+
+	for last.Next != nil {
+		last = last.Next
+	}
+
+	last.Next = task
+	task.Prev = last
+}
+
+// inserts the task in the subtasks of parent, before "at" or at the end when "at" is nil
+func (board *Board) insertTask(parent, task, at *Task) {
+	if at == nil {
+		board.appendTask(parent, task)
+	} else {
+		board.insertTaskBefore(task, at)
+	}
+}
+
+// appends to "tasks" every task starting at "first", each one followed by its subtasks.
+// when onlyVisible is true, hidden tasks and the subtasks of folded tasks are skipped
+// visible tasks follow the board sort (see SetSort)
+func (board *Board) collectTasks(first *Task, onlyVisible bool, tasks []*Task) []*Task {
+	var siblings []*Task
+
+	for current := first; current != nil; current = current.Next {
+		siblings = append(siblings, current)
+	}
+
+	if onlyVisible {
+		board.sortSiblings(siblings)
+	}
+
+	for _, current := range siblings {
+		if !onlyVisible || board.isVisible(current) {
+			tasks = append(tasks, current)
+		}
+
+		if !onlyVisible || !current.Folded {
+			tasks = board.collectTasks(current.Children, onlyVisible, tasks)
+		}
+	}
+
+	return tasks
+}
+
+func (board *Board) findTaskById(id int) *Task {
+	for _, task := range board.collectTasks(board.root, false, nil) {
+		if task.Id == id {
+			return task
+		}
+	}
+
+	return nil
+}
+
+// adds a new subtask at the end of the subtasks of the selected task and selects it
+func (board *Board) AddSubtask(name string) (Task, error) {
+	parent := board.task
+
+	if parent == nil {
+		return Task{}, errors.New("You have no selected task")
+	}
+
+	board.saveUndoPoint()
+
+	task := &Task{
+		Id:        board.idCluster.NewId(),
+		Name:      name,
+		State:     board.workflow.Initial(),
+		Priority:  PriorityNone,
+		CreatedAt: board.clock().Unix(),
+		Parent:    parent,
+	}
+
+	if board.priorityOrder {
+		board.insertTask(parent, task, board.priorityPositionFor(task))
+	} else {
+		board.appendTask(parent, task)
+	}
+
+	parent.Folded = false
+	board.task = task
+
+	board.record(task, HistoryCreated, "", task.Name)
+	board.ensureVisibleSelection()
+
+	return *task, nil
+}
+
+// hides the subtasks of the selected task
+func (board *Board) FoldCurrentTask() error {
+	if board.task == nil {
+		return errors.New("You have no selected task")
+	}
+
+	if !board.task.HasSubtasks() {
+		return errors.New("This task has no subtasks")
+	}
+
+	if board.task.Folded {
+		return errors.New("This task is already folded")
+	}
+
+	board.task.Folded = true
+
+	return nil
+}
+
+// shows the subtasks of the selected task
+func (board *Board) UnfoldCurrentTask() error {
+	if board.task == nil {
+		return errors.New("You have no selected task")
+	}
+
+	if !board.task.HasSubtasks() {
+		return errors.New("This task has no subtasks")
+	}
+
+	if !board.task.Folded {
+		return errors.New("This task is not folded")
+	}
+
+	board.task.Folded = false
+
+	return nil
+}
+
+// selects the parent of the selected task
+func (board *Board) SelectParentTask() error {
+	if board.task == nil {
+		return errors.New("You have no selected task")
+	}
+
+	if board.task.Parent == nil {
+		return errors.New("This task has no parent")
+	}
+
+	board.task = board.task.Parent
+	board.ensureVisibleSelection()
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package taskmanagement
+
+import (
+	"testing"
+)
+
+func TestAddSubtaskAppendsUnderSelectedTask(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("b")
+	board.AddTask("a")
+
+	if _, err := board.AddSubtask("a.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	board.SelectParentTask()
+	board.AddSubtask("a.2")
+
+	expectTaskNames(t, board.Tasks(), "a", "a.1", "a.2", "b")
+
+	if board.CurrentTask().Depth() != 1 {
+		t.Fatalf("Expected: %d, Received: %d", 1, board.CurrentTask().Depth())
+	}
+}
+
+func TestFoldHidesSubtasksFromNavigation(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("b")
+	board.AddTask("a")
+	board.AddSubtask("a.1")
+	board.SelectParentTask()
+
+	if err := board.FoldCurrentTask(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectTaskNames(t, board.VisibleTasks(), "a", "b")
+
+	board.SelectNextTask()
+
+	if board.CurrentTask().Name != "b" {
+		t.Fatalf("Expected: %v, Received: %v", "b", board.CurrentTask().Name)
+	}
+
+	board.SelectPreviousTask()
+	board.UnfoldCurrentTask()
+	board.SelectNextTask()
+
+	if board.CurrentTask().Name != "a.1" {
+		t.Fatalf("Expected: %v, Received: %v", "a.1", board.CurrentTask().Name)
+	}
+}
+
+func TestDeleteLastSubtaskSelectsParent(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.AddSubtask("a.1")
+
+	if err := board.DeleteCurrentSelectedTask(); err != nil {
+		t.Fatal(err)
+	}
+
+	if board.CurrentTask().Name != "a" || board.CurrentTask().HasSubtasks() {
+		t.Fatalf("Expected: %v, Received: %v", "a", board.CurrentTask().Name)
+	}
+}
@@ -1,28 +1,69 @@
 package taskmanagement
 
 import (
+	"time"
+
 	"github.com/marcos-venicius/daily-term/idcluster"
 )
 
-// these are all task states
+// these are all task priorities, from the lowest to the highest
 const (
-	Todo       TaskState = iota
-	InProgress TaskState = iota
-	Completed  TaskState = iota
+	PriorityNone   TaskPriority = iota
+	PriorityLow    TaskPriority = iota
+	PriorityMedium TaskPriority = iota
+	PriorityHigh   TaskPriority = iota
+	PriorityUrgent TaskPriority = iota
 )
 
+// layout used to store and parse task due dates
+const DueDateLayout = "2006-01-02"
+
+// id of a state defined in the board Workflow
 type TaskState int
 
+type TaskPriority int
+
 type Task struct {
-	Id    int       `json:"id"`
-	Name  string    `json:"name"`
-	State TaskState `json:"state"` // default is Todo
-	Prev  *Task     `json:"prev"`  // previous task in the board
-	Next  *Task     `json:"next"`  // next task in the board
+	Id              int          `json:"id"`
+	Name            string       `json:"name"`
+	State           TaskState    `json:"state"`            // default is the first state of the workflow
+	Priority        TaskPriority `json:"priority"`         // default is PriorityNone
+	DueDate         string       `json:"due_date"`         // optional, formatted with DueDateLayout
+	Tags            []string     `json:"tags"`             // labels used to group and filter tasks
+	Notes           string       `json:"notes"`            // free-form, multi-line description
+	Recurrence      string       `json:"recurrence"`       // canonical recurrence rule (see ParseRecurrence), empty when the task does not repeat
+	TimeEntries     []TimeEntry  `json:"time_entries"`     // periods in which the task was in progress
+	CompletedAt     int64        `json:"completed_at"`     // unix timestamp of when the task was finished, 0 while it is not
+	CreatedAt       int64        `json:"created_at"`       // unix timestamp, 0 for tasks created before it was stored
+	BlockedBy       []int        `json:"blocked_by"`       // ids of the tasks that must be finished before this one
+	EstimatePoints  int          `json:"estimate_points"`  // effort in points, 0 when the task is not estimated in points
+	EstimateMinutes int          `json:"estimate_minutes"` // effort in minutes, 0 when the task is not estimated in hours
+	Folded          bool         `json:"folded"`           // hides the subtasks
+	Prev            *Task        `json:"prev"`             // previous task in the board (or in the parent subtasks)
+	Next            *Task        `json:"next"`             // next task in the board (or in the parent subtasks)
+	Parent          *Task        `json:"parent"`           // nil for top level tasks
+	Children        *Task        `json:"children"`         // first subtask
 }
 
 type Board struct {
-	task      *Task // current selected task
-	root      *Task // tree root node
-	idCluster *idcluster.IdCluster
+	name          string
+	task          *Task // current selected task
+	root          *Task // first top level task
+	idCluster     *idcluster.IdCluster
+	priorityOrder bool       // keeps higher priority tasks on top
+	tagFilter     string     // when not empty, only tasks with this tag are visible
+	stateFilter   *TaskState // when not nil, only tasks in this state are visible
+	textFilter    string     // when not empty, only tasks with this text in their name or notes are visible
+	clock         func() time.Time
+	workflow      *Workflow
+	autoArchive   int // finished tasks older than this many days are archived, 0 disables it
+	history       []HistoryEntry
+	undoStack     []boardSnapshot
+	redoStack     []boardSnapshot
+	day           string // date the board belongs to
+	days          []dayData
+	readOnly      bool   // boards of past days cannot be changed
+	sortKey       string // how visible tasks are sorted (see SetSort), empty keeps the board order
+	sortDesc      bool
+	revision      int // revision of the stored board this board was loaded from, see Repository.SaveBoard
 }
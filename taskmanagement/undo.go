@@ -0,0 +1,153 @@
+package taskmanagement
+
+import (
+	"errors"
+)
+
+// max number of changes that can be undone
+const maxUndoSteps = 100
+
+// copy of the board contents at some point in time.
+// the history is an append-only log and is not copied, undoing a change keeps the entries it recorded
+type boardSnapshot struct {
+	root          *Task
+	selectedId    int // -1 when no task is selected
+	priorityOrder bool
+	historySize   int // number of history entries, so a reverted change can drop the ones it recorded
+}
+
+// deep copy of the task list starting at first (with all subtasks)
+func cloneTasks(first, parent *Task) *Task {
+	var head, previous *Task
+
+	for current := first; current != nil; current = current.Next {
+		task := *current
+
+		task.Tags = append([]string(nil), current.Tags...)
+		task.TimeEntries = append([]TimeEntry(nil), current.TimeEntries...)
+		task.BlockedBy = append([]int(nil), current.BlockedBy...)
+		task.Parent = parent
+		task.Prev = previous
+		task.Next = nil
+		task.Children = cloneTasks(current.Children, &task)
+
+		if previous == nil {
+			head = &task
+		} else {
+			previous.Next = &task
+		}
+
+		previous = &task
+	}
+
+	return head
+}
+
+func (board *Board) snapshot() boardSnapshot {
+	selectedId := -1
+
+	if board.task != nil {
+		selectedId = board.task.Id
+	}
+
+	return boardSnapshot{
+		root:          cloneTasks(board.root, nil),
+		selectedId:    selectedId,
+		priorityOrder: board.priorityOrder,
+		historySize:   len(board.history),
+	}
+}
+
+func (board *Board) restore(snapshot boardSnapshot) {
+	board.root = snapshot.root
+	board.priorityOrder = snapshot.priorityOrder
+
+	board.task = board.findTaskById(snapshot.selectedId)
+
+	if board.task == nil {
+		board.task = board.root
+	}
+
+	board.ensureVisibleSelection()
+}
+
+func pushSnapshot(stack []boardSnapshot, snapshot boardSnapshot) []boardSnapshot {
+	stack = append(stack, snapshot)
+
+	if len(stack) > maxUndoSteps {
+		stack = stack[1:]
+	}
+
+	return stack
+}
+
+// must be called right before every change that can be undone
+func (board *Board) saveUndoPoint() {
+	board.undoStack = pushSnapshot(board.undoStack, board.snapshot())
+	board.redoStack = nil
+}
+
+// used by changes that cannot be undone (like archiving) because they move tasks out of the board
+// or into it from the archive
+func (board *Board) ForgetUndoPoints() {
+	board.undoStack = nil
+	board.redoStack = nil
+}
+
+func (board *Board) CanUndo() bool {
+	return len(board.undoStack) > 0
+}
+
+func (board *Board) CanRedo() bool {
+	return len(board.redoStack) > 0
+}
+
+func (board *Board) Undo() error {
+	if !board.CanUndo() {
+		return errors.New("Nothing to undo")
+	}
+
+	last := len(board.undoStack) - 1
+	snapshot := board.undoStack[last]
+
+	board.undoStack = board.undoStack[:last]
+	board.redoStack = pushSnapshot(board.redoStack, board.snapshot())
+	board.restore(snapshot)
+
+	return nil
+}
+
+func (board *Board) Redo() error {
+	if !board.CanRedo() {
+		return errors.New("Nothing to redo")
+	}
+
+	last := len(board.redoStack) - 1
+	snapshot := board.redoStack[last]
+
+	board.redoStack = board.redoStack[:last]
+	board.undoStack = pushSnapshot(board.undoStack, board.snapshot())
+	board.restore(snapshot)
+
+	return nil
+}
+
+// undoes the last change without making it redoable, used when a change cannot be saved.
+// unlike Undo, the history entries of the change are dropped too since it never happened
+func (board *Board) RevertLastChange() error {
+	if !board.CanUndo() {
+		return errors.New("Nothing to revert")
+	}
+
+	last := len(board.undoStack) - 1
+	snapshot := board.undoStack[last]
+
+	board.undoStack = board.undoStack[:last]
+	board.restore(snapshot)
+
+	if snapshot.historySize < len(board.history) {
+		board.history = board.history[:snapshot.historySize]
+	}
+
+	return nil
+}
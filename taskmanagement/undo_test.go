@@ -0,0 +1,90 @@
+package taskmanagement
+
+import (
+	"testing"
+)
+
+func TestUndoAndRedoDelete(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.AddSubtask("child")
+	board.AddTask("b")
+
+	board.SelectNextTask()
+
+	if err := board.DeleteCurrentSelectedTask(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectTaskNames(t, board.Tasks(), "b")
+
+	if err := board.Undo(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectTaskNames(t, board.Tasks(), "b", "a", "child")
+
+	if board.CurrentTask().Name != "a" {
+		t.Fatalf("Expected: %v, Received: %v", "a", board.CurrentTask().Name)
+	}
+
+	if err := board.Redo(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectTaskNames(t, board.Tasks(), "b")
+
+	if err := board.Redo(); err == nil {
+		t.Fatal("Expected an error when there is nothing to redo")
+	}
+}
+
+func TestUndoStateMoveAndAdd(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.MoveCurrentSelectedTaskTo(completed)
+
+	board.Undo()
+
+	if board.CurrentTask().State != todo || board.CurrentTask().CompletedAt != 0 {
+		t.Fatalf("Expected: %v, Received: %v", todo, board.CurrentTask().State)
+	}
+
+	board.Undo()
+
+	if board.HasTasks() {
+		t.Fatal("Expected the board to be empty")
+	}
+
+	if err := board.Undo(); err == nil {
+		t.Fatal("Expected an error when there is nothing to undo")
+	}
+}
+
+func TestNewChangeClearsRedo(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+	board.Undo()
+	board.AddTask("b")
+
+	if board.CanRedo() {
+		t.Fatal("Expected the redo history to be cleared")
+	}
+}
+
+func TestRevertLastChangeIsNotRedoable(t *testing.T) {
+	board := CreateBoard()
+
+	board.AddTask("a")
+
+	if err := board.RevertLastChange(); err != nil {
+		t.Fatal(err)
+	}
+
+	if board.HasTasks() || board.CanRedo() {
+		t.Fatal("Expected the change to be reverted without redo")
+	}
+}
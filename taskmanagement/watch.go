@@ -0,0 +1,131 @@
+package taskmanagement
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+type DatabaseStatus int
+
+const (
+	DatabaseUnchanged DatabaseStatus = iota
+	DatabaseModified  DatabaseStatus = iota // written by another instance or tool
+	DatabaseDeleted   DatabaseStatus = iota
+)
+
+var errDatabaseExists = errors.New("The database already exists")
+
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func readFileStamp(path string) fileStamp {
+	stat, err := os.Stat(path)
+
+	if err != nil {
+		return fileStamp{}
+	}
+
+	return fileStamp{exists: true, size: stat.Size(), modTime: stat.ModTime()}
+}
+
+// tells whether the file changed since it was last stamped and stamps it again
+func checkFile(path string, last *fileStamp) DatabaseStatus {
+	stamp := readFileStamp(path)
+
+	if stamp == *last {
+		return DatabaseUnchanged
+	}
+
+	*last = stamp
+
+	if !stamp.exists {
+		return DatabaseDeleted
+	}
+
+	return DatabaseModified
+}
+
+// tells whether the database file changed since this instance last wrote or checked it.
+// it is meant to be polled, each change is reported only once
+func (r *Repository) CheckDatabase() DatabaseStatus {
+	return checkFile(r.path, &r.stamp)
+}
+
+// same as CheckDatabase, for the archive file
+func (r *Repository) CheckArchive() DatabaseStatus {
+	return checkFile(r.archivePath, &r.archiveStamp)
+}
+
+// revision of the stored board with the given name, -1 when it is not stored
+func (r *Repository) BoardRevision(name string) (int, error) {
+	database, err := r.readDatabase()
+
+	if err != nil {
+		return -1, err
+	}
+
+	index := database.find(name)
+
+	if index == -1 {
+		return -1, nil
+	}
+
+	return database.Boards[index].Revision, nil
+}
+
+// writes the database again after it was deleted, with the given board as the active one.
+// the other boards come from the newest backup when there is one
+func (r *Repository) RecreateDatabase(board *Board) error {
+	if board.readOnly {
+		return errors.New("Past days are read-only, use :today to go back")
+	}
+
+	stored := board.data()
+
+	err := r.updateDatabase(func(database *databaseData) error {
+		if readFileStamp(r.path).exists {
+			return errDatabaseExists
+		}
+
+		if bytes, err := os.ReadFile(r.backupPath(1)); err == nil {
+			if backup, err := decodeDatabase(bytes); err == nil {
+				*database = *backup
+			}
+		}
+
+		database.ActiveBoard = board.name
+
+		if index := database.find(board.name); index != -1 {
+			database.Boards[index] = stored
+		} else {
+			database.Boards = append(database.Boards, stored)
+		}
+
+		database.normalize()
+
+		return nil
+	})
+
+	if err == errDatabaseExists {
+		// another instance recreated it first, its boards are kept and reported by CheckDatabase
+		r.stamp = fileStamp{}
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	board.revision = stored.Revision
+
+	return nil
+}
+
+func (board *Board) Revision() int {
+	return board.revision
+}
@@ -0,0 +1,89 @@
+package taskmanagement
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCheckDatabaseIgnoresOwnSaves(t *testing.T) {
+	repository := createTestRepository(t)
+
+	saveTaskNames(t, repository, "a")
+
+	if status := repository.CheckDatabase(); status != DatabaseUnchanged {
+		t.Fatalf("Expected: %v, Received: %v", DatabaseUnchanged, status)
+	}
+}
+
+func TestCheckDatabaseReportsChangesOnce(t *testing.T) {
+	repository := createTestRepository(t)
+	other := &Repository{path: repository.path}
+
+	saveTaskNames(t, repository, "a")
+	saveTaskNames(t, other, "a", "b")
+
+	// the modification time may have a coarse resolution, the size changed anyway
+	os.Chtimes(repository.path, time.Now(), time.Now().Add(time.Second))
+
+	if status := repository.CheckDatabase(); status != DatabaseModified {
+		t.Fatalf("Expected: %v, Received: %v", DatabaseModified, status)
+	}
+
+	if status := repository.CheckDatabase(); status != DatabaseUnchanged {
+		t.Fatalf("Expected: %v, Received: %v", DatabaseUnchanged, status)
+	}
+
+	if err := os.Remove(repository.path); err != nil {
+		t.Fatal(err)
+	}
+
+	if status := repository.CheckDatabase(); status != DatabaseDeleted {
+		t.Fatalf("Expected: %v, Received: %v", DatabaseDeleted, status)
+	}
+}
+
+func TestRecreateDatabaseKeepsBoardsFromBackup(t *testing.T) {
+	repository := createTestRepository(t)
+
+	if err := repository.AddBoard("work"); err != nil {
+		t.Fatal(err)
+	}
+
+	board := CreateBoard()
+	repository.LoadBoard(board)
+	board.AddTask("a")
+
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(repository.path); err != nil {
+		t.Fatal(err)
+	}
+
+	board.AddTask("b")
+
+	if err := repository.RecreateDatabase(board); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := repository.BoardNames()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 2 || names[0] != DefaultBoardName || names[1] != "work" {
+		t.Fatalf("Expected: %v, Received: %v", []string{DefaultBoardName, "work"}, names)
+	}
+
+	loaded := CreateBoard()
+	repository.LoadBoard(loaded)
+	expectTaskNames(t, loaded.Tasks(), "b", "a")
+
+	// the recreated board can still be saved
+	if err := repository.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,203 @@
+package taskmanagement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// a task state defined by the user
+type StateDefinition struct {
+	Id          TaskState `json:"id"`          // value stored in the tasks, must be unique
+	Name        string    `json:"name"`        // must be unique (case insensitive)
+	Color       string    `json:"color"`       // white, yellow, green, red, blue, cyan, magenta...
+	Key         string    `json:"key"`         // key that moves the selected task to this state in NORMAL mode
+	Transitions []string  `json:"transitions"` // names of the states a task may move to, empty means any state
+	Done        bool      `json:"done"`        // tasks in this state are finished
+	Tracked     bool      `json:"tracked"`     // the task clock runs while the task is in this state
+}
+
+// all the states a task may be in, the first one is the state of new tasks
+type Workflow struct {
+	states []StateDefinition
+}
+
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		states: []StateDefinition{
+			{Id: 0, Name: "Todo", Color: "white", Key: "t"},
+			{Id: 1, Name: "In progress", Color: "yellow", Key: "i", Tracked: true},
+			{Id: 2, Name: "Completed", Color: "green", Key: "c", Done: true},
+		},
+	}
+}
+
+func CreateWorkflow(states []StateDefinition) (*Workflow, error) {
+	if len(states) == 0 {
+		return nil, errors.New("The workflow needs at least one state")
+	}
+
+	ids := map[TaskState]bool{}
+	names := map[string]bool{}
+	keys := map[string]bool{}
+
+	for _, state := range states {
+		name := compactStateName(state.Name)
+
+		if name == "" {
+			return nil, errors.New(fmt.Sprintf("State %d has no name", state.Id))
+		}
+
+		if ids[state.Id] {
+			return nil, errors.New(fmt.Sprintf("State id %d is used more than once", state.Id))
+		}
+
+		if names[name] {
+			return nil, errors.New(fmt.Sprintf(`State name "%v" is used more than once`, state.Name))
+		}
+
+		if state.Key != "" {
+			if utf8.RuneCountInString(state.Key) != 1 {
+				return nil, errors.New(fmt.Sprintf(`Key of state "%v" must be a single character`, state.Name))
+			}
+
+			if keys[state.Key] {
+				return nil, errors.New(fmt.Sprintf(`Key "%v" is used by more than one state`, state.Key))
+			}
+
+			keys[state.Key] = true
+		}
+
+		ids[state.Id] = true
+		names[name] = true
+	}
+
+	for _, state := range states {
+		for _, transition := range state.Transitions {
+			if !names[compactStateName(transition)] {
+				return nil, errors.New(fmt.Sprintf(`State "%v" moves to unknown state "%v"`, state.Name, transition))
+			}
+		}
+	}
+
+	return &Workflow{states: states}, nil
+}
+
+// reads the workflow from a json file with a list of state definitions
+func loadWorkflowFile(path string) (*Workflow, error) {
+	bytes, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var states []StateDefinition
+
+	if err = json.Unmarshal(bytes, &states); err != nil {
+		return nil, errors.New(fmt.Sprintf("Invalid states file: %v", err.Error()))
+	}
+
+	return CreateWorkflow(states)
+}
+
+func writeWorkflowFile(path string, workflow *Workflow) error {
+	bytes, err := json.MarshalIndent(workflow.states, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, bytes, 0600)
+}
+
+func (workflow *Workflow) States() []StateDefinition {
+	return workflow.states
+}
+
+// state given to new tasks
+func (workflow *Workflow) Initial() TaskState {
+	return workflow.states[0].Id
+}
+
+func (workflow *Workflow) State(id TaskState) (StateDefinition, bool) {
+	for _, state := range workflow.states {
+		if state.Id == id {
+			return state, true
+		}
+	}
+
+	return StateDefinition{}, false
+}
+
+// "In progress" is the same name as "inprogress" or "in-progress"
+func compactStateName(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+}
+
+func (workflow *Workflow) StateByName(name string) (StateDefinition, bool) {
+	name = compactStateName(name)
+
+	for _, state := range workflow.states {
+		if compactStateName(state.Name) == name {
+			return state, true
+		}
+	}
+
+	return StateDefinition{}, false
+}
+
+func (workflow *Workflow) StateByKey(key rune) (StateDefinition, bool) {
+	for _, state := range workflow.states {
+		if state.Key == string(key) {
+			return state, true
+		}
+	}
+
+	return StateDefinition{}, false
+}
+
+func (workflow *Workflow) Name(id TaskState) string {
+	if state, ok := workflow.State(id); ok {
+		return state.Name
+	}
+
+	return "Unknown"
+}
+
+func (workflow *Workflow) IsDone(id TaskState) bool {
+	state, ok := workflow.State(id)
+
+	return ok && state.Done
+}
+
+func (workflow *Workflow) IsTracked(id TaskState) bool {
+	state, ok := workflow.State(id)
+
+	return ok && state.Tracked
+}
+
+// tells if a task may go from one state to the other, tasks in unknown states may go anywhere
+func (workflow *Workflow) CanMove(from, to TaskState) bool {
+	state, ok := workflow.State(from)
+
+	if !ok || len(state.Transitions) == 0 {
+		return true
+	}
+
+	target, ok := workflow.State(to)
+
+	if !ok {
+		return false
+	}
+
+	for _, transition := range state.Transitions {
+		if compactStateName(transition) == compactStateName(target.Name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+package taskmanagement
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func createReviewWorkflow(t *testing.T) *Workflow {
+	workflow, err := CreateWorkflow([]StateDefinition{
+		{Id: 0, Name: "Todo", Key: "t", Transitions: []string{"Review", "Blocked"}},
+		{Id: 1, Name: "Review", Key: "r", Transitions: []string{"Todo", "Blocked", "Done"}, Tracked: true},
+		{Id: 2, Name: "Blocked", Key: "b", Transitions: []string{"Todo"}},
+		{Id: 3, Name: "Done", Key: "x", Done: true},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return workflow
+}
+
+func TestCreateWorkflowValidatesStates(t *testing.T) {
+	invalid := [][]StateDefinition{
+		{},
+		{{Id: 0, Name: ""}},
+		{{Id: 0, Name: "Todo"}, {Id: 0, Name: "Done"}},
+		{{Id: 0, Name: "Todo"}, {Id: 1, Name: "todo"}},
+		{{Id: 0, Name: "Todo", Key: "t"}, {Id: 1, Name: "Done", Key: "t"}},
+		{{Id: 0, Name: "Todo", Key: "to"}},
+		{{Id: 0, Name: "Todo", Transitions: []string{"Review"}}},
+	}
+
+	for _, states := range invalid {
+		if _, err := CreateWorkflow(states); err == nil {
+			t.Fatalf("Error expected for %+v but received nil", states)
+		}
+	}
+}
+
+func TestMoveCurrentSelectedTaskFollowsTransitions(t *testing.T) {
+	board := CreateBoard()
+	board.SetWorkflow(createReviewWorkflow(t))
+
+	board.AddTask("a")
+
+	if _, err := board.MoveCurrentSelectedTaskTo(3); err == nil {
+		t.Fatal("Error expected but received nil")
+	}
+
+	if _, err := board.MoveCurrentSelectedTaskTo(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if !board.CurrentTask().IsTracking() {
+		t.Fatal("Expected the task clock to be running")
+	}
+
+	if _, err := board.MoveCurrentSelectedTaskTo(3); err != nil {
+		t.Fatal(err)
+	}
+
+	if !board.IsTaskDone(board.CurrentTask()) {
+		t.Fatal("Expected the task to be done")
+	}
+
+	if _, err := board.MoveCurrentSelectedTaskTo(42); err == nil {
+		t.Fatal("Error expected but received nil")
+	}
+}
+
+func TestWorkflowFileRoundTrip(t *testing.T) {
+	filePath := path.Join(t.TempDir(), statesFileName)
+
+	if err := writeWorkflowFile(filePath, createReviewWorkflow(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	workflow, err := loadWorkflowFile(filePath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if state, ok := workflow.StateByKey('b'); !ok || state.Name != "Blocked" {
+		t.Fatalf("Expected: %v, Received: %+v", "Blocked", state)
+	}
+
+	os.WriteFile(filePath, []byte("{"), 0600)
+
+	if _, err := loadWorkflowFile(filePath); err == nil {
+		t.Fatal("Error expected but received nil")
+	}
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"strings"
+	"unicode/utf8"
+
 	"github.com/mattn/go-runewidth"
 	"github.com/nsf/termbox-go"
-	"unicode/utf8"
 )
 
 func tbprint(x, y int, fg, bg termbox.Attribute, msg string) {
@@ -69,3 +71,94 @@ func byte_slice_insert(text []byte, offset int, what []byte) []byte {
 	copy(text[offset:], what)
 	return text
 }
+
+// same as tbprint, but stops drawing when the text reaches the given width
+func tbprintn(x, y, w int, fg, bg termbox.Attribute, msg string) {
+	limit := x + w
+
+	for _, c := range msg {
+		if x+runewidth.RuneWidth(c) > limit {
+			return
+		}
+
+		termbox.SetCell(x, y, c, fg, bg)
+		x += runewidth.RuneWidth(c)
+	}
+}
+
+// breaks the text in lines that fit in the given width, respecting existing line breaks
+func wrapText(text string, width int) []string {
+	var lines []string
+
+	if width < 1 {
+		return lines
+	}
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		lineWidth := 0
+
+		for _, word := range strings.Fields(paragraph) {
+			wordWidth := runewidth.StringWidth(word)
+
+			if lineWidth > 0 && lineWidth+1+wordWidth > width {
+				lines = append(lines, line)
+				line, lineWidth = "", 0
+			}
+
+			for wordWidth > width {
+				head := runewidth.Truncate(word, width, "")
+
+				if head == "" {
+					_, size := utf8.DecodeRuneInString(word)
+					head = word[:size]
+				}
+
+				lines = append(lines, head)
+				word = word[len(head):]
+				wordWidth = runewidth.StringWidth(word)
+			}
+
+			if lineWidth > 0 {
+				line += " "
+				lineWidth++
+			}
+
+			line += word
+			lineWidth += wordWidth
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+var colorNames = map[string]termbox.Attribute{
+	"default":       termbox.ColorDefault,
+	"black":         termbox.ColorBlack,
+	"red":           termbox.ColorRed,
+	"green":         termbox.ColorGreen,
+	"yellow":        termbox.ColorYellow,
+	"blue":          termbox.ColorBlue,
+	"magenta":       termbox.ColorMagenta,
+	"cyan":          termbox.ColorCyan,
+	"white":         termbox.ColorWhite,
+	"dark-gray":     termbox.ColorDarkGray,
+	"light-red":     termbox.ColorLightRed,
+	"light-green":   termbox.ColorLightGreen,
+	"light-yellow":  termbox.ColorLightYellow,
+	"light-blue":    termbox.ColorLightBlue,
+	"light-magenta": termbox.ColorLightMagenta,
+	"light-cyan":    termbox.ColorLightCyan,
+	"light-gray":    termbox.ColorLightGray,
+}
+
+// color of a state by its name, unknown names are white
+func stateColor(name string) termbox.Attribute {
+	if color, ok := colorNames[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return color
+	}
+
+	return termbox.ColorWhite
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"time"
+
+	"github.com/marcos-venicius/daily-term/taskmanagement"
+)
+
+// how often the database file is checked for changes made outside this instance
+const databaseCheckInterval = time.Second
+
+// reloads the board and the archive when their files changed on disk and writes them again when they were deleted
+func (editor *Editor) checkDatabase() {
+	editor.checkArchive()
+
+	switch editor.repository.CheckDatabase() {
+	case taskmanagement.DatabaseModified:
+		live := editor.currentDayBoard()
+
+		revision, err := editor.repository.BoardRevision(live.Name())
+
+		// a corrupt file is recovered by the reload
+		if _, invalid := err.(*taskmanagement.InvalidDatabaseError); !invalid {
+			if editor.setErrorMessageIfNNil(err) {
+				return
+			}
+
+			// changes to other boards do not change what is shown
+			if revision == live.Revision() {
+				return
+			}
+		}
+
+		editor.reloadBoard()
+
+		if editor.errorMessage == "" {
+			editor.SetInfoMessage("board reloaded, the database was changed outside this window")
+		}
+	case taskmanagement.DatabaseDeleted:
+		if !editor.setErrorMessageIfNNil(editor.repository.RecreateDatabase(editor.currentDayBoard())) {
+			editor.SetInfoMessage("the database was deleted, it was written again from this window")
+		}
+	}
+}
+
+func (editor *Editor) checkArchive() {
+	switch editor.repository.CheckArchive() {
+	case taskmanagement.DatabaseModified:
+		archive, err := editor.repository.LoadArchive()
+
+		// the corrupt file was moved aside, it is written again from memory
+		if _, corrupt := err.(*taskmanagement.CorruptDatabaseError); corrupt {
+			editor.showBanner(err)
+			editor.setErrorMessageIfNNil(editor.repository.SaveArchive(editor.archive))
+			return
+		}
+
+		if editor.setErrorMessageIfNNil(err) {
+			return
+		}
+
+		editor.archive = archive
+		editor.currentDayBoard().ReserveTaskIds(archive.TaskIds())
+
+		if editor.archiveSelection >= len(archive.Entries()) {
+			editor.archiveSelection = max(len(archive.Entries())-1, 0)
+		}
+
+		if editor.mode.IsArchive() && archive.IsEmpty() {
+			editor.SetNormalMode()
+		}
+	case taskmanagement.DatabaseDeleted:
+		editor.setErrorMessageIfNNil(editor.repository.SaveArchive(editor.archive))
+	}
+}